@@ -0,0 +1,125 @@
+package gen
+
+// CharacterProfile describes the character that item conditions are checked against.
+// Characteristics are keyed by their condition code, for example "cs" for strength or "cp" for action points.
+type CharacterProfile struct {
+	Level           int            `json:"level"`
+	Characteristics map[string]int `json:"characteristics"`
+	Subscribed      bool           `json:"subscribed"`
+	AlignmentLevel  int            `json:"alignment_level"`
+	MountId         *int           `json:"mount_id"`
+	AreaId          *int           `json:"area_id"`
+}
+
+type ConditionResult struct {
+	Condition MappedMultilangCondition `json:"condition"`
+	Passed    bool                     `json:"passed"`
+	Evaluated bool                     `json:"evaluated"` // false when the profile does not hold the needed information
+}
+
+func compareCondition(actual int, operator string, expected int) bool {
+	switch operator {
+	case "<":
+		return actual < expected
+	case ">":
+		return actual > expected
+	case "=":
+		return actual == expected
+	case "!":
+		return actual != expected
+	}
+	return false
+}
+
+// BoolToInt turns true into 1 and false into 0.
+func BoolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// EvaluateCondition checks a single parsed condition against the profile. Conditions that cannot be
+// answered with the profile, like quests, owned items or a missing area, are not evaluated and count as passed.
+func EvaluateCondition(condition *MappedMultilangCondition, profile *CharacterProfile) ConditionResult {
+	result := ConditionResult{
+		Condition: *condition,
+		Passed:    true,
+	}
+
+	var actual int
+	switch condition.Family {
+	case ConditionFamilyCharacteristic:
+		actual = profile.Characteristics[condition.Element]
+	case ConditionFamilyLevel:
+		actual = profile.Level
+	case ConditionFamilySubscription:
+		actual = BoolToInt(profile.Subscribed)
+	case ConditionFamilyAlignmentLevel:
+		actual = profile.AlignmentLevel
+	case ConditionFamilyArea:
+		if profile.AreaId == nil {
+			return result
+		}
+		actual = *profile.AreaId
+	case ConditionFamilyMount: // equipped mount, "!" for a mount that must not be equipped
+		if profile.MountId == nil {
+			result.Evaluated = true
+			result.Passed = condition.Operator == "!"
+			return result
+		}
+		actual = *profile.MountId
+	default:
+		return result
+	}
+
+	result.Evaluated = true
+	result.Passed = compareCondition(actual, condition.Operator, condition.Value)
+	return result
+}
+
+// EvaluateConditions checks every condition of an item. All conditions have to pass for the item to be usable.
+func EvaluateConditions(conditions []MappedMultilangCondition, profile *CharacterProfile) ([]ConditionResult, bool) {
+	results := make([]ConditionResult, 0, len(conditions))
+	passed := true
+	for i := range conditions {
+		result := EvaluateCondition(&conditions[i], profile)
+		passed = passed && result.Passed
+		results = append(results, result)
+	}
+	return results, passed
+}
+
+// EvaluateConditionTree resolves the relations of the tree, so only one side of an "or" has to pass.
+// Unknown leaves and conditions that the profile can not answer are not evaluated. They count as passed,
+// but the result is only reported as evaluated when it does not depend on them.
+func EvaluateConditionTree(node *MappedMultilangConditionNode, profile *CharacterProfile) (passed bool, evaluated bool) {
+	if node == nil {
+		return true, true
+	}
+	if node.Condition != nil {
+		result := EvaluateCondition(node.Condition, profile)
+		return result.Passed, result.Evaluated
+	}
+	if node.Relation == "" {
+		return true, false
+	}
+
+	// an evaluated child that passes an "or" or fails an "and" decides the relation on its own
+	decisive := node.Relation == CriteriaOr
+	evaluated = true
+	for i := range node.Children {
+		childPassed, childEvaluated := EvaluateConditionTree(&node.Children[i], profile)
+		if !childEvaluated {
+			evaluated = false
+			continue
+		}
+		if childPassed == decisive {
+			return decisive, true
+		}
+	}
+	if evaluated {
+		return !decisive, true
+	}
+	return true, false
+}
@@ -0,0 +1,229 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	CriteriaAnd       = "and"
+	CriteriaOr        = "or"
+	CriteriaCondition = "condition"
+)
+
+// CriteriaNode is a node of the boolean expression tree that Ankama criteria strings like
+// "(CS>100|CA>100)&PL>50" describe. Leaves hold a single comparison, inner nodes combine their children.
+type CriteriaNode struct {
+	Type     string
+	Code     string
+	Operator string
+	Value    string
+	Children []*CriteriaNode
+}
+
+const criteriaOperators = "<>=!~"
+
+type criteriaParser struct {
+	tokens []string
+	pos    int
+}
+
+func tokenizeCriteria(criteria string) []string {
+	var tokens []string
+	var operand strings.Builder
+	flush := func() {
+		if trimmed := strings.TrimSpace(operand.String()); trimmed != "" {
+			tokens = append(tokens, trimmed)
+		}
+		operand.Reset()
+	}
+
+	for _, r := range criteria {
+		switch r {
+		case '(', ')', '&', '|':
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			operand.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+func (p *criteriaParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// parseOr handles the lowest precedence, "&" binds stronger than "|".
+func (p *criteriaParser) parseOr() (*CriteriaNode, error) {
+	return p.parseRelation(CriteriaOr, "|", p.parseAnd)
+}
+
+func (p *criteriaParser) parseAnd() (*CriteriaNode, error) {
+	return p.parseRelation(CriteriaAnd, "&", p.parsePrimary)
+}
+
+func (p *criteriaParser) parseRelation(relation string, token string, next func() (*CriteriaNode, error)) (*CriteriaNode, error) {
+	first, err := next()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &CriteriaNode{Type: relation, Children: []*CriteriaNode{first}}
+	for p.peek() == token {
+		p.pos++
+		child, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if child.Type == relation { // flatten a&(b&c) into a single node
+			node.Children = append(node.Children, child.Children...)
+		} else {
+			node.Children = append(node.Children, child)
+		}
+	}
+
+	if len(node.Children) == 1 {
+		return first, nil
+	}
+	return node, nil
+}
+
+func (p *criteriaParser) parsePrimary() (*CriteriaNode, error) {
+	token := p.peek()
+	switch token {
+	case "":
+		return nil, fmt.Errorf("unexpected end of criteria")
+	case "(":
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis at token %d", p.pos)
+		}
+		p.pos++
+		return node, nil
+	case ")", "&", "|":
+		return nil, fmt.Errorf("unexpected %q at token %d", token, p.pos)
+	}
+
+	p.pos++
+	return parseCriteriaOperand(token), nil
+}
+
+// parseCriteriaOperand splits a comparison like "CS>100" into code, operator and value.
+// Operands without an operator are kept with an empty operator so callers can skip them.
+func parseCriteriaOperand(operand string) *CriteriaNode {
+	node := &CriteriaNode{Type: CriteriaCondition, Code: operand}
+	idx := strings.IndexAny(operand, criteriaOperators)
+	if idx <= 0 {
+		return node
+	}
+	node.Code = strings.TrimSpace(operand[:idx])
+	node.Operator = operand[idx : idx+1]
+	node.Value = strings.TrimSpace(operand[idx+1:])
+	return node
+}
+
+// ParseCriteria builds the expression tree of a criteria string. An empty or "null" string results in a nil tree.
+func ParseCriteria(criteria string) (*CriteriaNode, error) {
+	criteria = strings.ReplaceAll(criteria, "\n", "")
+	if strings.TrimSpace(criteria) == "null" {
+		return nil, nil
+	}
+	tokens := tokenizeCriteria(criteria)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	parser := criteriaParser{tokens: tokens}
+	node, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos != len(tokens) {
+		return nil, fmt.Errorf("unexpected %q at token %d", parser.peek(), parser.pos)
+	}
+	return node, nil
+}
+
+// mapCriteriaNode templates the leaves of the tree. Comparisons that can not be mapped are kept as unknown
+// leaves, dropping them would turn the other side of an "or" into a mandatory condition.
+func mapCriteriaNode(node *CriteriaNode, langs *map[string]LangDict, data *JSONGameData) MappedMultilangConditionNode {
+	if node.Type == CriteriaCondition {
+		unknown := MappedMultilangConditionNode{Unknown: node.Code + node.Operator + node.Value}
+		if node.Operator == "" {
+			return unknown
+		}
+		var out MappedMultilangCondition
+		out.Templated = make(map[string]string)
+		part := strings.ToLower(node.Code + node.Operator + node.Value)
+		if !ConditionWithOperator(part, node.Operator, langs, &out, data) {
+			return unknown
+		}
+		return MappedMultilangConditionNode{Condition: &out}
+	}
+
+	children := make([]MappedMultilangConditionNode, 0, len(node.Children))
+	for _, child := range node.Children {
+		children = append(children, mapCriteriaNode(child, langs, data))
+	}
+	return MappedMultilangConditionNode{Relation: node.Type, Children: children}
+}
+
+func ParseConditionTree(criteria string, langs *map[string]LangDict, data *JSONGameData) *MappedMultilangConditionNode {
+	tree, err := ParseCriteria(criteria)
+	if err != nil || tree == nil {
+		return nil
+	}
+	mapped := mapCriteriaNode(tree, langs, data)
+	return &mapped
+}
+
+// FlattenConditionTree lists the conditions of the tree from left to right.
+func FlattenConditionTree(node *MappedMultilangConditionNode) []MappedMultilangCondition {
+	if node == nil {
+		return nil
+	}
+	if node.Condition != nil {
+		return []MappedMultilangCondition{*node.Condition}
+	}
+	var res []MappedMultilangCondition
+	for i := range node.Children {
+		res = append(res, FlattenConditionTree(&node.Children[i])...)
+	}
+	return res
+}
+
+func collectUnhandledCriteria(node *CriteriaNode, codes map[string]int) {
+	if node.Type == CriteriaCondition {
+		if node.Operator != "" && !IsCriterionHandled(node.Code, node.Value) {
+			codes[strings.ToLower(node.Code)]++
+		}
+		return
+	}
+	for _, child := range node.Children {
+		collectUnhandledCriteria(child, codes)
+	}
+}
+
+// UnhandledCriteria counts how often each criterion code that can not be mapped yet is used by the items.
+// Codes like "pj" count when their value misses what the mapping needs.
+func UnhandledCriteria(items map[int]JSONGameItem) map[string]int {
+	codes := make(map[string]int)
+	for _, item := range items {
+		tree, err := ParseCriteria(item.Criteria)
+		if err != nil || tree == nil {
+			continue
+		}
+		collectUnhandledCriteria(tree, codes)
+	}
+	return codes
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/dofusdude/api/utils"
 
 	"github.com/hashicorp/go-memdb"
-	"github.com/meilisearch/meilisearch-go"
 )
 
 func IndexApiData(done chan bool, indexed *bool, version *utils.VersionT) (*memdb.MemDB, map[string]SearchIndexes) {
@@ -19,6 +18,16 @@ func IndexApiData(done chan bool, indexed *bool, version *utils.VersionT) (*memd
 	var sets []MappedMultilangSet
 	var recipes []MappedMultilangRecipe
 	var mounts []MappedMultilangMount
+	var spells []MappedMultilangSpell
+	var breeds []MappedMultilangBreed
+	var monsters []MappedMultilangMonster
+	var almanax []MappedMultilangAlmanax
+	var idols []MappedMultilangIdol
+	var companions []MappedMultilangCompanion
+	var areas []MappedMultilangArea
+	var superAreas []MappedMultilangSuperArea
+	var npcs []MappedMultilangNpc
+	var jobs []MappedMultilangJob
 
 	log.Println("generating Database and search index ...")
 	// --
@@ -56,7 +65,7 @@ func IndexApiData(done chan bool, indexed *bool, version *utils.VersionT) (*memd
 	if err != nil {
 		fmt.Println(err)
 	}
-	
+
 	log.Println("loaded ", len(recipes), " recipes")
 
 	// --
@@ -72,8 +81,138 @@ func IndexApiData(done chan bool, indexed *bool, version *utils.VersionT) (*memd
 
 	log.Println("loaded ", len(mounts), " mounts")
 
+	// --
+	file, err = os.ReadFile("data/MAPPED_SPELLS.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &spells)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(spells), " spells")
+
+	// --
+	file, err = os.ReadFile("data/MAPPED_BREEDS.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &breeds)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(breeds), " breeds")
+
+	// --
+	file, err = os.ReadFile("data/MAPPED_MONSTERS.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &monsters)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(monsters), " monsters")
+
+	// --
+	file, err = os.ReadFile("data/MAPPED_ALMANAX.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &almanax)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(almanax), " almanax days")
+
+	// --
+	file, err = os.ReadFile("data/MAPPED_IDOLS.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &idols)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(idols), " idols")
+
+	// --
+	file, err = os.ReadFile("data/MAPPED_COMPANIONS.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &companions)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(companions), " companions")
+
+	// --
+	file, err = os.ReadFile("data/MAPPED_AREAS.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &areas)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(areas), " areas")
+
+	// --
+	file, err = os.ReadFile("data/MAPPED_SUPER_AREAS.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &superAreas)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(superAreas), " super areas")
+
+	// --
+	file, err = os.ReadFile("data/MAPPED_NPCS.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &npcs)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(npcs), " npcs")
+
+	// --
+	file, err = os.ReadFile("data/MAPPED_JOBS.json")
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	err = json.Unmarshal(file, &jobs)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	log.Println("loaded ", len(jobs), " jobs")
+
 	startDatabaseIndex := time.Now()
-	db, indexes := GenerateDatabase(&items, &sets, &recipes, &mounts, indexed, version, done)
+	db, indexes := GenerateDatabase(&items, &sets, &recipes, &mounts, &spells, &breeds, &monsters, &almanax, &idols, &companions, &areas, &superAreas, &npcs, &jobs, indexed, version, done)
 	log.Println("... completed indexing in", time.Since(startDatabaseIndex))
 
 	return db, indexes
@@ -262,6 +401,226 @@ func GetMemDBSchema() *memdb.DBSchema {
 					},
 				},
 			},
+			"red-spells": &memdb.TableSchema{
+				Name: "red-spells",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"blue-spells": &memdb.TableSchema{
+				Name: "blue-spells",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"red-classes": &memdb.TableSchema{
+				Name: "red-classes",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"blue-classes": &memdb.TableSchema{
+				Name: "blue-classes",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"red-monsters": &memdb.TableSchema{
+				Name: "red-monsters",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"blue-monsters": &memdb.TableSchema{
+				Name: "blue-monsters",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"red-almanax": &memdb.TableSchema{
+				Name: "red-almanax",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "Id"},
+					},
+					"date": &memdb.IndexSchema{
+						Name:   "date",
+						Unique: true,
+						Indexer: &memdb.CompoundIndex{
+							Indexes: []memdb.Indexer{
+								&memdb.IntFieldIndex{Field: "Month"},
+								&memdb.IntFieldIndex{Field: "Day"},
+							},
+						},
+					},
+				},
+			},
+			"blue-almanax": &memdb.TableSchema{
+				Name: "blue-almanax",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "Id"},
+					},
+					"date": &memdb.IndexSchema{
+						Name:   "date",
+						Unique: true,
+						Indexer: &memdb.CompoundIndex{
+							Indexes: []memdb.Indexer{
+								&memdb.IntFieldIndex{Field: "Month"},
+								&memdb.IntFieldIndex{Field: "Day"},
+							},
+						},
+					},
+				},
+			},
+			"red-idols": &memdb.TableSchema{
+				Name: "red-idols",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"blue-idols": &memdb.TableSchema{
+				Name: "blue-idols",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"red-companions": &memdb.TableSchema{
+				Name: "red-companions",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"blue-companions": &memdb.TableSchema{
+				Name: "blue-companions",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"red-areas": &memdb.TableSchema{
+				Name: "red-areas",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"blue-areas": &memdb.TableSchema{
+				Name: "blue-areas",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"red-superareas": &memdb.TableSchema{
+				Name: "red-superareas",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"blue-superareas": &memdb.TableSchema{
+				Name: "blue-superareas",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"red-npcs": &memdb.TableSchema{
+				Name: "red-npcs",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"blue-npcs": &memdb.TableSchema{
+				Name: "blue-npcs",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"red-jobs": &memdb.TableSchema{
+				Name: "red-jobs",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
+			"blue-jobs": &memdb.TableSchema{
+				Name: "blue-jobs",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": &memdb.IndexSchema{
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.IntFieldIndex{Field: "AnkamaId"},
+					},
+				},
+			},
 			"effect-condition-elements": &memdb.TableSchema{
 				Name: "effect-condition-elements",
 				Indexes: map[string]*memdb.IndexSchema{
@@ -288,13 +647,44 @@ func GetItemSuperType(id int) int {
 	return 0
 }
 
+func createSearchIndex(config *utils.SearchIndexConfig) utils.SearchIndex {
+	index, err := utils.Search.CreateIndex(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return index
+}
+
+// searchIndexedEffects lists the distinct lowercase effect names in the language and the distinct element ids.
+func searchIndexedEffects(effects []MappedMultilangEffect, lang string) ([]string, []int) {
+	names := make([]string, 0)
+	elementIds := make([]int, 0)
+	seenNames := make(map[string]bool)
+	seenIds := make(map[int]bool)
+	for _, effect := range effects {
+		name := strings.ToLower(strings.TrimSpace(effect.Type[lang]))
+		if name != "" && !seenNames[name] {
+			seenNames[name] = true
+			names = append(names, name)
+		}
+		if effect.ElementId >= 0 && !seenIds[effect.ElementId] {
+			seenIds[effect.ElementId] = true
+			elementIds = append(elementIds, effect.ElementId)
+		}
+	}
+	return names, elementIds
+}
+
 type SearchIndexes struct {
-	AllItems *meilisearch.Index
-	Sets     *meilisearch.Index
-	Mounts   *meilisearch.Index
+	AllItems   utils.SearchIndex
+	Sets       utils.SearchIndex
+	Mounts     utils.SearchIndex
+	Spells     utils.SearchIndex
+	Monsters   utils.SearchIndex
+	Companions utils.SearchIndex
 }
 
-func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet, recipes *[]MappedMultilangRecipe, mounts *[]MappedMultilangMount, indexed *bool, version *utils.VersionT, done chan bool) (*memdb.MemDB, map[string]SearchIndexes) {
+func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet, recipes *[]MappedMultilangRecipe, mounts *[]MappedMultilangMount, spells *[]MappedMultilangSpell, breeds *[]MappedMultilangBreed, monsters *[]MappedMultilangMonster, almanax *[]MappedMultilangAlmanax, idols *[]MappedMultilangIdol, companions *[]MappedMultilangCompanion, areas *[]MappedMultilangArea, superAreas *[]MappedMultilangSuperArea, npcs *[]MappedMultilangNpc, jobs *[]MappedMultilangJob, indexed *bool, version *utils.VersionT, done chan bool) (*memdb.MemDB, map[string]SearchIndexes) {
 	/*
 		item_category_mapping := hashbidimap.New()
 		item_category_Put(0, 862817) // Ausrüstung
@@ -306,97 +696,50 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 	*/
 
 	multilangSearchIndexes := make(map[string]SearchIndexes)
-	var indexTasks []*meilisearch.TaskInfo
+	var indexTasks []utils.SearchTask
 	*indexed = false
 
-	client := utils.CreateMeiliClient()
-
 	for _, lang := range utils.Languages {
-		itemIndexUid := fmt.Sprintf("%s-all_items-%s", utils.NextRedBlueVersionStr(version.Search), lang)
-		setIndexUid := fmt.Sprintf("%s-sets-%s", utils.NextRedBlueVersionStr(version.Search), lang)
-		mountIndexUid := fmt.Sprintf("%s-mounts-%s", utils.NextRedBlueVersionStr(version.Search), lang)
-
-		// creation
-		createItemsIdxTask, err := client.CreateIndex(&meilisearch.IndexConfig{
-			Uid:        itemIndexUid,
-			PrimaryKey: "id",
-		})
-		if err != nil {
-			log.Fatal(err)
-		}
-		_, err = client.WaitForTask(createItemsIdxTask.TaskUID)
-		if err != nil {
-			log.Fatal(err)
-		}
+		searchVersion := utils.NextRedBlueVersionStr(version.Search)
 
-		createSetsIdxTask, err := client.CreateIndex(&meilisearch.IndexConfig{
-			Uid:        setIndexUid,
-			PrimaryKey: "id",
+		allItemsIdx := createSearchIndex(&utils.SearchIndexConfig{
+			Uid:        fmt.Sprintf("%s-all_items-%s", searchVersion, lang),
+			Searchable: []string{"name", "type_name", "super_type", "effects", "description"},
+			Filterable: []string{"super_type", "type_name", "level", "pods", "type_id", "super_type_id", "has_parent_set", "has_recipe", "effects", "effect_element_ids"},
+			Facets:     []string{"type_name", "super_type", "level"},
 		})
-		if err != nil {
-			log.Fatal(err)
-		}
-		_, err = client.WaitForTask(createSetsIdxTask.TaskUID)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		createMountIdxTask, err := client.CreateIndex(&meilisearch.IndexConfig{
-			Uid:        mountIndexUid,
-			PrimaryKey: "id",
+		setsIdx := createSearchIndex(&utils.SearchIndexConfig{
+			Uid:        fmt.Sprintf("%s-sets-%s", searchVersion, lang),
+			Searchable: []string{"name", "effects"},
+			Filterable: []string{"highest_equipment_level", "effects", "effect_element_ids"},
 		})
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		// wait for creation end
-		_, err = client.WaitForTask(createItemsIdxTask.TaskUID)
-		if err != nil {
-			log.Fatal(err)
-		}
-		_, err = client.WaitForTask(createSetsIdxTask.TaskUID)
-		if err != nil {
-			log.Fatal(err)
-		}
-		_, err = client.WaitForTask(createMountIdxTask.TaskUID)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		// add filters
-		allItemsIdx := client.Index(itemIndexUid)
-		_, err = allItemsIdx.UpdateFilterableAttributes(&[]string{
-			"super_type",
-			"type_name",
-			"level",
+		mountsIdx := createSearchIndex(&utils.SearchIndexConfig{
+			Uid:        fmt.Sprintf("%s-mounts-%s", searchVersion, lang),
+			Searchable: []string{"name", "family_name", "effects"},
+			Filterable: []string{"family_name", "family_id", "effects", "effect_element_ids"},
 		})
-		if err != nil {
-			log.Println(err)
-			return nil, nil
-		}
-
-		mountsIdx := client.Index(mountIndexUid)
-		_, err = mountsIdx.UpdateFilterableAttributes(&[]string{
-			"family_name",
+		spellsIdx := createSearchIndex(&utils.SearchIndexConfig{
+			Uid:        fmt.Sprintf("%s-spells-%s", searchVersion, lang),
+			Searchable: []string{"name", "type_name", "description"},
+			Filterable: []string{"type_name"},
 		})
-		if err != nil {
-			log.Println(err)
-			return nil, nil
-		}
-
-		setsIdx := client.Index(setIndexUid)
-		_, err = setsIdx.UpdateFilterableAttributes(&[]string{
-			"highest_equipment_level",
+		monstersIdx := createSearchIndex(&utils.SearchIndexConfig{
+			Uid:        fmt.Sprintf("%s-monsters-%s", searchVersion, lang),
+			Searchable: []string{"name", "race_name"},
+			Filterable: []string{"race_name", "level"},
+		})
+		companionsIdx := createSearchIndex(&utils.SearchIndexConfig{
+			Uid:        fmt.Sprintf("%s-companions-%s", searchVersion, lang),
+			Searchable: []string{"name"},
 		})
-		if err != nil {
-			log.Println(err)
-			return nil, nil
-		}
 
 		multilangSearchIndexes[lang] = SearchIndexes{
-			AllItems: allItemsIdx,
-			Sets:     setsIdx,
-			Mounts:   mountsIdx,
+			AllItems:   allItemsIdx,
+			Sets:       setsIdx,
+			Mounts:     mountsIdx,
+			Spells:     spellsIdx,
+			Monsters:   monstersIdx,
+			Companions: companionsIdx,
 		}
 	}
 
@@ -430,12 +773,24 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 	setsTable := fmt.Sprintf("%s-sets", utils.NextRedBlueVersionStr(version.MemDb))
 	mountsTable := fmt.Sprintf("%s-mounts", utils.NextRedBlueVersionStr(version.MemDb))
 	recipesTable := fmt.Sprintf("%s-recipes", utils.NextRedBlueVersionStr(version.MemDb))
+	spellsTable := fmt.Sprintf("%s-spells", utils.NextRedBlueVersionStr(version.MemDb))
+	classesTable := fmt.Sprintf("%s-classes", utils.NextRedBlueVersionStr(version.MemDb))
+	monstersTable := fmt.Sprintf("%s-monsters", utils.NextRedBlueVersionStr(version.MemDb))
+	almanaxTable := fmt.Sprintf("%s-almanax", utils.NextRedBlueVersionStr(version.MemDb))
+	idolsTable := fmt.Sprintf("%s-idols", utils.NextRedBlueVersionStr(version.MemDb))
+	companionsTable := fmt.Sprintf("%s-companions", utils.NextRedBlueVersionStr(version.MemDb))
+	areasTable := fmt.Sprintf("%s-areas", utils.NextRedBlueVersionStr(version.MemDb))
+	superAreasTable := fmt.Sprintf("%s-superareas", utils.NextRedBlueVersionStr(version.MemDb))
+	npcsTable := fmt.Sprintf("%s-npcs", utils.NextRedBlueVersionStr(version.MemDb))
+	jobsTable := fmt.Sprintf("%s-jobs", utils.NextRedBlueVersionStr(version.MemDb))
 
+	hasRecipe := make(map[int]bool)
 	for _, recipe := range *recipes {
 		recipeCt := recipe
 		if err := txn.Insert(recipesTable, &recipeCt); err != nil {
 			panic(err)
 		}
+		hasRecipe[recipeCt.ResultId] = true
 	}
 
 	for _, item := range *items {
@@ -455,13 +810,22 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 		}
 
 		for _, lang := range utils.Languages {
+			effectNames, effectElementIds := searchIndexedEffects(itemCp.Effects, lang)
 			object := SearchIndexedItem{
-				Name:        itemCp.Name[lang],
-				Id:          itemCp.AnkamaId,
-				Description: itemCp.Description[lang],
-				SuperType:   insertCategoryTable,
-				TypeName:    strings.ToLower(itemCp.Type.Name[lang]),
-				Level:       itemCp.Level,
+				Name:         itemCp.Name[lang],
+				Id:           itemCp.AnkamaId,
+				Description:  itemCp.Description[lang],
+				SuperType:    insertCategoryTable,
+				TypeName:     strings.ToLower(itemCp.Type.Name[lang]),
+				Level:        itemCp.Level,
+				Pods:         itemCp.Pods,
+				TypeId:       itemCp.Type.ItemTypeId,
+				SuperTypeId:  itemCp.Type.SuperTypeId,
+				HasParentSet: itemCp.HasParentSet,
+				HasRecipe:    hasRecipe[itemCp.AnkamaId],
+
+				Effects:          effectNames,
+				EffectElementIds: effectElementIds,
 			}
 
 			itemIndexBatch[lang] = append(itemIndexBatch[lang], object)
@@ -469,8 +833,9 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 				taskInfo, err := multilangSearchIndexes[lang].AllItems.AddDocuments(itemIndexBatch[lang])
 				if err != nil {
 					log.Println(err)
+				} else {
+					indexTasks = append(indexTasks, taskInfo)
 				}
-				indexTasks = append(indexTasks, taskInfo)
 				itemIndexBatch[lang] = nil
 			}
 
@@ -484,11 +849,20 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 			panic(err)
 		}
 
+		var setEffects []MappedMultilangEffect
+		for _, tierEffects := range setCp.Effects {
+			setEffects = append(setEffects, tierEffects...)
+		}
+
 		for _, lang := range utils.Languages {
+			effectNames, effectElementIds := searchIndexedEffects(setEffects, lang)
 			object := SearchIndexedSet{
 				Name:  setCp.Name[lang],
 				Id:    setCp.AnkamaId,
 				Level: setCp.Level,
+
+				Effects:          effectNames,
+				EffectElementIds: effectElementIds,
 			}
 
 			setIndexBatch[lang] = append(setIndexBatch[lang], object)
@@ -496,8 +870,9 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 				taskInfo, err := multilangSearchIndexes[lang].Sets.AddDocuments(setIndexBatch[lang])
 				if err != nil {
 					log.Println(err)
+				} else {
+					indexTasks = append(indexTasks, taskInfo)
 				}
-				indexTasks = append(indexTasks, taskInfo)
 				setIndexBatch[lang] = nil
 			}
 		}
@@ -511,10 +886,15 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 		}
 
 		for _, lang := range utils.Languages {
+			effectNames, effectElementIds := searchIndexedEffects(mountCp.Effects, lang)
 			object := SearchIndexedMount{
 				Name:       mountCp.Name[lang],
 				Id:         mountCp.AnkamaId,
 				FamilyName: strings.ToLower(mountCp.FamilyName[lang]),
+				FamilyId:   mountCp.FamilyId,
+
+				Effects:          effectNames,
+				EffectElementIds: effectElementIds,
 			}
 
 			mountIndexBatch[lang] = append(mountIndexBatch[lang], object)
@@ -522,13 +902,150 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 				taskInfo, err := multilangSearchIndexes[lang].Mounts.AddDocuments(mountIndexBatch[lang])
 				if err != nil {
 					log.Println(err)
+				} else {
+					indexTasks = append(indexTasks, taskInfo)
 				}
-				indexTasks = append(indexTasks, taskInfo)
 				mountIndexBatch[lang] = nil
 			}
 		}
 	}
 
+	spellIndexBatch := make(map[string][]SearchIndexedSpell)
+	for _, spell := range *spells {
+		spellCp := spell
+		if err := txn.Insert(spellsTable, &spellCp); err != nil {
+			panic(err)
+		}
+
+		for _, lang := range utils.Languages {
+			object := SearchIndexedSpell{
+				Name:        spellCp.Name[lang],
+				Id:          spellCp.AnkamaId,
+				Description: spellCp.Description[lang],
+				TypeName:    strings.ToLower(spellCp.Type.LongName[lang]),
+			}
+
+			spellIndexBatch[lang] = append(spellIndexBatch[lang], object)
+			if len(spellIndexBatch[lang]) >= maxBatchSize {
+				taskInfo, err := multilangSearchIndexes[lang].Spells.AddDocuments(spellIndexBatch[lang])
+				if err != nil {
+					log.Println(err)
+				} else {
+					indexTasks = append(indexTasks, taskInfo)
+				}
+				spellIndexBatch[lang] = nil
+			}
+		}
+	}
+
+	for _, breed := range *breeds {
+		breedCp := breed
+		if err := txn.Insert(classesTable, &breedCp); err != nil {
+			panic(err)
+		}
+	}
+
+	monsterIndexBatch := make(map[string][]SearchIndexedMonster)
+	for _, monster := range *monsters {
+		monsterCp := monster
+		if err := txn.Insert(monstersTable, &monsterCp); err != nil {
+			panic(err)
+		}
+
+		level := 0
+		if len(monsterCp.Grades) > 0 {
+			level = monsterCp.Grades[0].Level
+		}
+
+		for _, lang := range utils.Languages {
+			object := SearchIndexedMonster{
+				Name:     monsterCp.Name[lang],
+				Id:       monsterCp.AnkamaId,
+				RaceName: strings.ToLower(monsterCp.Race.Name[lang]),
+				Level:    level,
+			}
+
+			monsterIndexBatch[lang] = append(monsterIndexBatch[lang], object)
+			if len(monsterIndexBatch[lang]) >= maxBatchSize {
+				taskInfo, err := multilangSearchIndexes[lang].Monsters.AddDocuments(monsterIndexBatch[lang])
+				if err != nil {
+					log.Println(err)
+				} else {
+					indexTasks = append(indexTasks, taskInfo)
+				}
+				monsterIndexBatch[lang] = nil
+			}
+		}
+	}
+
+	for _, day := range *almanax {
+		dayCp := day
+		if err := txn.Insert(almanaxTable, &dayCp); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, idol := range *idols {
+		idolCp := idol
+		if err := txn.Insert(idolsTable, &idolCp); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, area := range *areas {
+		areaCp := area
+		if err := txn.Insert(areasTable, &areaCp); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, superArea := range *superAreas {
+		superAreaCp := superArea
+		if err := txn.Insert(superAreasTable, &superAreaCp); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, npc := range *npcs {
+		npcCp := npc
+		if err := txn.Insert(npcsTable, &npcCp); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, job := range *jobs {
+		jobCp := job
+		if err := txn.Insert(jobsTable, &jobCp); err != nil {
+			panic(err)
+		}
+	}
+
+	companionIndexBatch := make(map[string][]SearchIndexedCompanion)
+	for _, companion := range *companions {
+		companionCp := companion
+		if err := txn.Insert(companionsTable, &companionCp); err != nil {
+			panic(err)
+		}
+
+		for _, lang := range utils.Languages {
+			object := SearchIndexedCompanion{
+				Name: companionCp.Name[lang],
+				Id:   companionCp.AnkamaId,
+			}
+
+			companionIndexBatch[lang] = append(companionIndexBatch[lang], object)
+			if len(companionIndexBatch[lang]) >= maxBatchSize {
+				taskInfo, err := multilangSearchIndexes[lang].Companions.AddDocuments(companionIndexBatch[lang])
+				if err != nil {
+					log.Println(err)
+				} else {
+					indexTasks = append(indexTasks, taskInfo)
+				}
+				companionIndexBatch[lang] = nil
+			}
+		}
+	}
+
 	txn.Commit()
 
 	// add everything not indexed because still under max batch size
@@ -537,30 +1054,56 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 			taskInfo, err := multilangSearchIndexes[lang].AllItems.AddDocuments(itemIndexBatch[lang])
 			if err != nil {
 				log.Println(err)
+			} else {
+				indexTasks = append(indexTasks, taskInfo)
 			}
-			indexTasks = append(indexTasks, taskInfo)
 		}
 
 		if len(setIndexBatch[lang]) > 0 {
 			taskInfo, err := multilangSearchIndexes[lang].Sets.AddDocuments(setIndexBatch[lang])
 			if err != nil {
 				log.Println(err)
+			} else {
+				indexTasks = append(indexTasks, taskInfo)
 			}
-			indexTasks = append(indexTasks, taskInfo)
 		}
 		if len(mountIndexBatch[lang]) > 0 {
 			taskInfo, err := multilangSearchIndexes[lang].Mounts.AddDocuments(mountIndexBatch[lang])
 			if err != nil {
 				log.Println(err)
+			} else {
+				indexTasks = append(indexTasks, taskInfo)
+			}
+		}
+		if len(spellIndexBatch[lang]) > 0 {
+			taskInfo, err := multilangSearchIndexes[lang].Spells.AddDocuments(spellIndexBatch[lang])
+			if err != nil {
+				log.Println(err)
+			} else {
+				indexTasks = append(indexTasks, taskInfo)
+			}
+		}
+		if len(monsterIndexBatch[lang]) > 0 {
+			taskInfo, err := multilangSearchIndexes[lang].Monsters.AddDocuments(monsterIndexBatch[lang])
+			if err != nil {
+				log.Println(err)
+			} else {
+				indexTasks = append(indexTasks, taskInfo)
+			}
+		}
+		if len(companionIndexBatch[lang]) > 0 {
+			taskInfo, err := multilangSearchIndexes[lang].Companions.AddDocuments(companionIndexBatch[lang])
+			if err != nil {
+				log.Println(err)
+			} else {
+				indexTasks = append(indexTasks, taskInfo)
 			}
-			indexTasks = append(indexTasks, taskInfo)
 		}
 	}
 
 	// wait for all indexing tasks to finish in the background
 	if len(indexTasks) > 0 {
 		ticker := time.NewTicker(3 * time.Second)
-		//go func(done chan bool, indexed *bool, client *meilisearch.Client, ticker *time.Ticker) {
 		var awaited []bool
 		firstRun := true
 		staySelectLoop := true
@@ -576,13 +1119,11 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 						continue
 					}
 
-					waitingForSucceededOrFailed := true
-					taskResp, err := client.GetTask(task.TaskUID)
+					waitingForSucceededOrFailed, err := task.Finished()
 					if err != nil {
 						log.Println(err)
 						break
 					}
-					waitingForSucceededOrFailed = taskResp.Status == meilisearch.TaskStatusSucceeded || taskResp.Status == meilisearch.TaskStatusFailed
 					if !waitingForSucceededOrFailed {
 						allTrue = false
 					}
@@ -604,7 +1145,6 @@ func GenerateDatabase(items *[]MappedMultilangItem, sets *[]MappedMultilangSet,
 				}
 			}
 		}
-		//}(done, indexed, client, ticker)
 	} else {
 		close(done)
 	}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/dofusdude/api/utils"
 	"log"
+	"sort"
+	"time"
 )
 
 func MapSets(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangSet {
@@ -12,7 +14,14 @@ func MapSets(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangSe
 		var mappedSet MappedMultilangSet
 		mappedSet.AnkamaId = set.Id
 		mappedSet.ItemIds = set.ItemIds
-		mappedSet.Effects = ParseEffects(data, set.Effects, langs)
+		for i, tier := range set.Effects {
+			tierEffects := ParseEffects(data, [][]JSONGameItemPossibleEffect{tier}, langs)
+			if tierEffects == nil {
+				continue // empty tiers are dropped, so remember how many pieces each kept tier needs
+			}
+			mappedSet.Effects = append(mappedSet.Effects, tierEffects[0])
+			mappedSet.EffectPieces = append(mappedSet.EffectPieces, i+1)
+		}
 
 		highestLevel := 0
 		for _, item := range set.ItemIds {
@@ -44,6 +53,9 @@ func MapRecipes(data *JSONGameData) []MappedMultilangRecipe {
 		ingredientCount := len(recipe.IngredientIds)
 		var mappedRecipe MappedMultilangRecipe
 		mappedRecipe.ResultId = recipe.Id
+		mappedRecipe.JobId = recipe.JobId
+		mappedRecipe.SkillId = recipe.SkillId
+		mappedRecipe.Level = recipe.Level
 		mappedRecipe.Entries = make([]MappedMultilangRecipeEntry, ingredientCount)
 		for i := 0; i < ingredientCount; i++ {
 			var recipeEntry MappedMultilangRecipeEntry
@@ -163,9 +175,607 @@ func MapItems(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangI
 		}
 
 		if len(item.Criteria) != 0 && mappedItems[idx].Type.Name["de"] != "Verwendbarer Temporis-Gegenstand" { // TODO Temporis got some weird conditions, need to play to see the items, not in normal game
-			mappedItems[idx].Conditions = ParseCondition(item.Criteria, langs, data)
+			mappedItems[idx].ConditionsTree = ParseConditionTree(item.Criteria, langs, data)
+			mappedItems[idx].Conditions = FlattenConditionTree(mappedItems[idx].ConditionsTree)
 		}
 	}
 
 	return mappedItems
 }
+
+func MapSpells(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangSpell {
+	var mappedSpells []MappedMultilangSpell
+	for _, spell := range data.spells {
+		if (*langs)["fr"].Texts[spell.NameId] == "" {
+			continue // skip unnamed internal spells
+		}
+
+		var mappedSpell MappedMultilangSpell
+		mappedSpell.AnkamaId = spell.Id
+		mappedSpell.Order = spell.Order
+		mappedSpell.IconId = spell.IconId
+		mappedSpell.Image = fmt.Sprintf("https://static.ankama.com/dofus/www/game/spells/55/sort_%d.png", spell.IconId)
+		mappedSpell.SpellLevels = spell.SpellLevels
+		mappedSpell.Name = make(map[string]string)
+		mappedSpell.Description = make(map[string]string)
+
+		spellType := data.spellTypes[spell.TypeId]
+		mappedSpell.Type.Id = spell.TypeId
+		mappedSpell.Type.LongName = make(map[string]string)
+		mappedSpell.Type.ShortName = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedSpell.Name[lang] = (*langs)[lang].Texts[spell.NameId]
+			mappedSpell.Description[lang] = (*langs)[lang].Texts[spell.DescriptionId]
+			mappedSpell.Type.LongName[lang] = (*langs)[lang].Texts[spellType.LongNameId]
+			mappedSpell.Type.ShortName[lang] = (*langs)[lang].Texts[spellType.ShortNameId]
+		}
+
+		mappedSpells = append(mappedSpells, mappedSpell)
+	}
+
+	if len(mappedSpells) == 0 {
+		return nil
+	}
+
+	return mappedSpells
+}
+
+func MapBreeds(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangBreed {
+	var mappedBreeds []MappedMultilangBreed
+	for _, breed := range data.classes {
+		var mappedBreed MappedMultilangBreed
+		mappedBreed.AnkamaId = breed.Id
+		mappedBreed.ShortName = make(map[string]string)
+		mappedBreed.LongName = make(map[string]string)
+		mappedBreed.Description = make(map[string]string)
+		mappedBreed.GameplayDescription = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedBreed.ShortName[lang] = (*langs)[lang].Texts[breed.ShortNameId]
+			mappedBreed.LongName[lang] = (*langs)[lang].Texts[breed.LongNameId]
+			mappedBreed.Description[lang] = (*langs)[lang].Texts[breed.DescriptionId]
+			mappedBreed.GameplayDescription[lang] = (*langs)[lang].Texts[breed.GameplayDescriptionId]
+		}
+
+		for _, spellId := range breed.BreedSpellsId {
+			spell, ok := data.spells[spellId]
+			if !ok || (*langs)["fr"].Texts[spell.NameId] == "" {
+				continue // only link spells that are exposed in the spells table
+			}
+			mappedBreed.SpellIds = append(mappedBreed.SpellIds, spellId)
+		}
+
+		mappedBreeds = append(mappedBreeds, mappedBreed)
+	}
+
+	if len(mappedBreeds) == 0 {
+		return nil
+	}
+
+	return mappedBreeds
+}
+
+func MapMonsters(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangMonster {
+	var mappedMonsters []MappedMultilangMonster
+	for _, monster := range data.Monsters {
+		if (*langs)["fr"].Texts[monster.NameId] == "" {
+			continue // skip unnamed internal monsters
+		}
+
+		var mappedMonster MappedMultilangMonster
+		mappedMonster.AnkamaId = monster.Id
+		mappedMonster.GfxId = monster.GfxId
+		mappedMonster.Image = fmt.Sprintf("https://static.ankama.com/dofus/www/game/monsters/200/%d.png", monster.GfxId)
+		mappedMonster.IsBoss = monster.IsBoss
+		mappedMonster.IsMiniBoss = monster.IsMiniBoss
+		mappedMonster.IsQuestMonster = monster.IsQuestMonster
+		mappedMonster.Name = make(map[string]string)
+		mappedMonster.Race.Id = monster.Race
+		mappedMonster.Race.Name = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedMonster.Name[lang] = (*langs)[lang].Texts[monster.NameId]
+			mappedMonster.Race.Name[lang] = (*langs)[lang].Texts[data.monsterRaces[monster.Race].NameId]
+		}
+
+		for _, grade := range monster.Grades {
+			mappedMonster.Grades = append(mappedMonster.Grades, MappedMultilangMonsterGrade{
+				Grade:             grade.Grade,
+				Level:             grade.Level,
+				LifePoints:        grade.LifePoints,
+				ActionPoints:      grade.ActionPoints,
+				MovementPoints:    grade.MovementPoints,
+				ApDodge:           grade.PaDodge,
+				MpDodge:           grade.PmDodge,
+				Wisdom:            grade.Wisdom,
+				Experience:        grade.GradeXp,
+				NeutralResistance: grade.NeutralResistance,
+				EarthResistance:   grade.EarthResistance,
+				FireResistance:    grade.FireResistance,
+				WaterResistance:   grade.WaterResistance,
+				AirResistance:     grade.AirResistance,
+			})
+		}
+
+		for _, drop := range monster.Drops {
+			percentages := []float64{
+				drop.PercentDropForGrade1,
+				drop.PercentDropForGrade2,
+				drop.PercentDropForGrade3,
+				drop.PercentDropForGrade4,
+				drop.PercentDropForGrade5,
+			}
+			if len(monster.Grades) > 0 && len(monster.Grades) < len(percentages) {
+				percentages = percentages[:len(monster.Grades)]
+			}
+			mappedMonster.Drops = append(mappedMonster.Drops, MappedMultilangMonsterDrop{
+				ItemId:      drop.ObjectId,
+				Count:       drop.Count,
+				Percentages: percentages,
+				HasCriteria: drop.HasCriteria,
+			})
+		}
+
+		mappedMonsters = append(mappedMonsters, mappedMonster)
+	}
+
+	if len(mappedMonsters) == 0 {
+		return nil
+	}
+
+	return mappedMonsters
+}
+
+const questObjectiveBringItemToNpc = 3 // parameters: npc id, item id, quantity
+
+const almanaxQuestCategoryId = 31
+
+// AlmanaxCalendarDate resolves the day of a calendar entry. The ids count the days of a leap year,
+// so 60 is February 29th and 366 is December 31st.
+func AlmanaxCalendarDate(id int) (time.Month, int, bool) {
+	if id < 1 || id > 366 {
+		return 0, 0, false
+	}
+	date := time.Date(2000, time.January, id, 0, 0, 0, 0, time.UTC) // 2000 is a leap year
+	return date.Month(), date.Day(), true
+}
+
+// almanaxOfferings collects the items that the almanax quests ask to bring to the npc of the day.
+// Only quests of the almanax category are used and the first objective by id wins for each npc.
+func almanaxOfferings(data *JSONGameData) map[int]MappedMultilangAlmanaxOffering {
+	if _, ok := data.questCategories[almanaxQuestCategoryId]; !ok {
+		log.Println("almanax quest category", almanaxQuestCategoryId, "not found, the calendar has no offerings")
+	}
+
+	almanaxSteps := make(map[int]bool)
+	for _, quest := range data.quests {
+		if quest.CategoryId != almanaxQuestCategoryId {
+			continue
+		}
+		for _, stepId := range quest.StepIds {
+			almanaxSteps[stepId] = true
+		}
+	}
+
+	var objectiveIds []int
+	for id, objective := range data.questObjectives {
+		if objective.TypeId == questObjectiveBringItemToNpc && almanaxSteps[objective.StepId] {
+			objectiveIds = append(objectiveIds, id)
+		}
+	}
+	sort.Ints(objectiveIds)
+
+	offerings := make(map[int]MappedMultilangAlmanaxOffering)
+	for _, id := range objectiveIds {
+		parameters := data.questObjectives[id].Parameters
+		if _, ok := offerings[parameters.Parameter0]; ok {
+			continue
+		}
+		offerings[parameters.Parameter0] = MappedMultilangAlmanaxOffering{
+			ItemId:   parameters.Parameter1,
+			Quantity: parameters.Parameter2,
+		}
+	}
+	return offerings
+}
+
+func MapAlmanax(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangAlmanax {
+	offerings := almanaxOfferings(data)
+
+	var mappedAlmanax []MappedMultilangAlmanax
+	for _, calendar := range data.almanax {
+		month, day, ok := AlmanaxCalendarDate(calendar.Id)
+		if !ok {
+			log.Println("almanax calendar", calendar.Id, "is not a day of the year")
+			continue
+		}
+
+		var mappedDay MappedMultilangAlmanax
+		mappedDay.Id = calendar.Id
+		mappedDay.Month = int(month)
+		mappedDay.Day = day
+		mappedDay.NpcId = calendar.NpcId
+		mappedDay.Bonus = make(map[string]string)
+		mappedDay.BonusDescription = make(map[string]string)
+		mappedDay.NpcName = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedDay.Bonus[lang] = (*langs)[lang].Texts[calendar.NameId]
+			mappedDay.BonusDescription[lang] = (*langs)[lang].Texts[calendar.DescId]
+			mappedDay.NpcName[lang] = (*langs)[lang].Texts[data.npcs[calendar.NpcId].NameId]
+		}
+
+		offering, ok := offerings[calendar.NpcId]
+		mappedDay.HasOffering = ok
+		if ok {
+			mappedDay.Offering = offering
+		}
+
+		mappedAlmanax = append(mappedAlmanax, mappedDay)
+	}
+
+	if len(mappedAlmanax) == 0 {
+		return nil
+	}
+
+	return mappedAlmanax
+}
+
+func MapIdols(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangIdol {
+	var mappedIdols []MappedMultilangIdol
+	for _, idol := range data.idols {
+		item, ok := data.Items[idol.ItemId]
+		if !ok || (*langs)["fr"].Texts[item.NameId] == "" {
+			continue // idols are only identifiable through their item
+		}
+
+		var mappedIdol MappedMultilangIdol
+		mappedIdol.AnkamaId = idol.Id
+		mappedIdol.ItemId = idol.ItemId
+		mappedIdol.IconId = item.IconId
+		mappedIdol.Image = fmt.Sprintf("https://static.ankama.com/dofus/www/game/items/200/%d.png", item.IconId)
+		mappedIdol.CategoryId = idol.CategoryId
+		mappedIdol.Level = item.Level
+		mappedIdol.Score = idol.Score
+		mappedIdol.ExperienceBonus = idol.ExperienceBonus
+		mappedIdol.DropBonus = idol.DropBonus
+		mappedIdol.GroupOnly = idol.GroupOnly
+		mappedIdol.Name = make(map[string]string)
+		mappedIdol.Description = make(map[string]string)
+
+		spellPair := data.spellPairs[idol.SpellPairId]
+		mappedIdol.Spell.Id = idol.SpellPairId
+		mappedIdol.Spell.IconId = spellPair.IconId
+		mappedIdol.Spell.Name = make(map[string]string)
+		mappedIdol.Spell.Description = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedIdol.Name[lang] = (*langs)[lang].Texts[item.NameId]
+			mappedIdol.Description[lang] = (*langs)[lang].Texts[item.DescriptionId]
+			mappedIdol.Spell.Name[lang] = (*langs)[lang].Texts[spellPair.NameId]
+			mappedIdol.Spell.Description[lang] = (*langs)[lang].Texts[spellPair.DescriptionId]
+		}
+
+		for i, synergyIdolId := range idol.SynergyIdolsIds {
+			if i >= len(idol.SynergyIdolsCoeff) {
+				break
+			}
+			mappedIdol.Synergies = append(mappedIdol.Synergies, MappedMultilangIdolSynergy{
+				IdolId:      synergyIdolId,
+				Coefficient: idol.SynergyIdolsCoeff[i],
+			})
+		}
+
+		mappedIdols = append(mappedIdols, mappedIdol)
+	}
+
+	if len(mappedIdols) == 0 {
+		return nil
+	}
+
+	return mappedIdols
+}
+
+const itemTypeCompanion = 169
+
+// companionUnlockItems maps the companion ids to the items that unlock them. These items are of the companion
+// item type and reference the companion id with the dice number of their effect.
+func companionUnlockItems(data *JSONGameData) map[int]int {
+	unlockItems := make(map[int]int)
+	for _, item := range data.Items {
+		if item.TypeId != itemTypeCompanion {
+			continue
+		}
+		for _, effect := range item.PossibleEffects {
+			if _, ok := data.companions[effect.MinimumValue]; !ok {
+				continue
+			}
+			// the lowest item id wins, so the mapping does not change between runs
+			if existing, ok := unlockItems[effect.MinimumValue]; !ok || item.Id < existing {
+				unlockItems[effect.MinimumValue] = item.Id
+			}
+			break
+		}
+	}
+	return unlockItems
+}
+
+func MapCompanions(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangCompanion {
+	unlockItems := companionUnlockItems(data)
+
+	var mappedCompanions []MappedMultilangCompanion
+	for _, companion := range data.companions {
+		frName := (*langs)["fr"].Texts[companion.NameId]
+		if frName == "" {
+			continue
+		}
+
+		var mappedCompanion MappedMultilangCompanion
+		mappedCompanion.AnkamaId = companion.Id
+		mappedCompanion.AssetId = companion.AssetId
+		mappedCompanion.StartingSpellLevelId = companion.StartingSpellLevelId
+		mappedCompanion.Name = make(map[string]string)
+		mappedCompanion.Description = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedCompanion.Name[lang] = (*langs)[lang].Texts[companion.NameId]
+			mappedCompanion.Description[lang] = (*langs)[lang].Texts[companion.DescriptionId]
+		}
+
+		for _, companionSpellId := range companion.Spells {
+			companionSpell, ok := data.companionSpells[companionSpellId]
+			if !ok {
+				continue
+			}
+			mappedCompanion.Spells = append(mappedCompanion.Spells, MappedMultilangCompanionSpell{
+				SpellId:      companionSpell.SpellId,
+				GradeByLevel: companionSpell.GradeByLevel,
+			})
+		}
+
+		for _, companionCharId := range companion.Characteristics {
+			companionChar, ok := data.companionChars[companionCharId]
+			if !ok {
+				continue
+			}
+
+			var mappedChar MappedMultilangCompanionCharacteristic
+			mappedChar.Id = companionChar.CaracId
+			mappedChar.Order = companionChar.Order
+			mappedChar.Name = make(map[string]string)
+			for _, lang := range utils.Languages {
+				mappedChar.Name[lang] = (*langs)[lang].Texts[data.characteristics[companionChar.CaracId].NameId]
+			}
+
+			for _, step := range companionChar.StatPerLevelRange {
+				if len(step) < 2 {
+					continue
+				}
+				mappedChar.Scaling = append(mappedChar.Scaling, MappedMultilangCompanionStatStep{
+					Level: step[0],
+					Value: step[1],
+				})
+			}
+
+			mappedCompanion.Characteristics = append(mappedCompanion.Characteristics, mappedChar)
+		}
+		sort.Slice(mappedCompanion.Characteristics, func(i, j int) bool {
+			return mappedCompanion.Characteristics[i].Order < mappedCompanion.Characteristics[j].Order
+		})
+
+		mappedCompanion.UnlockItemId, mappedCompanion.HasUnlockItem = unlockItems[companion.Id]
+		if !mappedCompanion.HasUnlockItem {
+			log.Println("companion", companion.Id, "has no unlock item")
+		}
+
+		mappedCompanions = append(mappedCompanions, mappedCompanion)
+	}
+
+	if len(mappedCompanions) == 0 {
+		return nil
+	}
+
+	return mappedCompanions
+}
+
+func MapAreas(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangArea {
+	var mappedAreas []MappedMultilangArea
+	for _, area := range data.areas {
+		var mappedArea MappedMultilangArea
+		mappedArea.AnkamaId = area.Id
+		mappedArea.SuperAreaId = area.SuperAreaId
+		mappedArea.ContainHouses = area.ContainHouses
+		mappedArea.ContainPaddocks = area.ContainPaddocks
+		mappedArea.WorldmapId = area.WorldmapId
+		mappedArea.HasWorldMap = area.HasWorldMap
+		mappedArea.Bounds = MappedMultilangAreaBounds{
+			X:      area.Bounds.X,
+			Y:      area.Bounds.Y,
+			Width:  area.Bounds.Width,
+			Height: area.Bounds.Height,
+		}
+		mappedArea.Name = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedArea.Name[lang] = (*langs)[lang].Texts[area.NameId]
+		}
+
+		mappedAreas = append(mappedAreas, mappedArea)
+	}
+
+	if len(mappedAreas) == 0 {
+		return nil
+	}
+
+	return mappedAreas
+}
+
+func MapSuperAreas(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangSuperArea {
+	areaIds := make(map[int][]int)
+	for _, area := range data.areas {
+		areaIds[area.SuperAreaId] = append(areaIds[area.SuperAreaId], area.Id)
+	}
+
+	var mappedSuperAreas []MappedMultilangSuperArea
+	for _, superArea := range data.superAreas {
+		var mappedSuperArea MappedMultilangSuperArea
+		mappedSuperArea.AnkamaId = superArea.Id
+		mappedSuperArea.WorldmapId = superArea.WorldmapId
+		mappedSuperArea.HasWorldMap = superArea.HasWorldMap
+		mappedSuperArea.AreaIds = areaIds[superArea.Id]
+		sort.Ints(mappedSuperArea.AreaIds)
+		mappedSuperArea.Name = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedSuperArea.Name[lang] = (*langs)[lang].Texts[superArea.NameId]
+		}
+
+		mappedSuperAreas = append(mappedSuperAreas, mappedSuperArea)
+	}
+
+	if len(mappedSuperAreas) == 0 {
+		return nil
+	}
+
+	return mappedSuperAreas
+}
+
+// npcMessageText resolves the text of a dialog message, messages point to NpcMessages.
+func npcMessageText(data *JSONGameData, langs *map[string]LangDict, lang string, messageRef int) string {
+	message, ok := data.npcMessages[messageRef]
+	if !ok {
+		return ""
+	}
+	return (*langs)[lang].Texts[message.MessageId]
+}
+
+// npcReplyText resolves the text of a dialog reply, replies point to the language dictionaries directly.
+func npcReplyText(langs *map[string]LangDict, lang string, textId int) string {
+	return (*langs)[lang].Texts[textId]
+}
+
+// mapNpcDialog builds the dialog tree of the npc. Message pairs are [message id, npc message id], reply pairs are
+// [reply id, text id] with the id of the message they answer as optional third value. Replies without a known
+// message are returned separately.
+func mapNpcDialog(data *JSONGameData, langs *map[string]LangDict, npc *JSONGameNPC) ([]MappedMultilangNpcDialogMessage, []MappedMultilangNpcDialogEntry) {
+	var messages []MappedMultilangNpcDialogMessage
+	messagePositions := make(map[int]int)
+	for _, pair := range npc.DialogMessages {
+		if len(pair) < 2 {
+			continue
+		}
+
+		message := MappedMultilangNpcDialogMessage{
+			Id:   pair[0],
+			Text: make(map[string]string),
+		}
+		for _, lang := range utils.Languages {
+			message.Text[lang] = npcMessageText(data, langs, lang, pair[1])
+		}
+		messagePositions[message.Id] = len(messages)
+		messages = append(messages, message)
+	}
+
+	var unlinked []MappedMultilangNpcDialogEntry
+	for _, pair := range npc.DialogReplies {
+		if len(pair) < 2 {
+			continue
+		}
+
+		reply := MappedMultilangNpcDialogEntry{
+			Id:   pair[0],
+			Text: make(map[string]string),
+		}
+		for _, lang := range utils.Languages {
+			reply.Text[lang] = npcReplyText(langs, lang, pair[1])
+		}
+
+		if len(pair) > 2 {
+			if position, ok := messagePositions[pair[2]]; ok {
+				messages[position].Replies = append(messages[position].Replies, reply)
+				continue
+			}
+		}
+		unlinked = append(unlinked, reply)
+	}
+
+	return messages, unlinked
+}
+
+func MapNpcs(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangNpc {
+	var mappedNpcs []MappedMultilangNpc
+	for _, npc := range data.npcs {
+		if (*langs)["fr"].Texts[npc.NameId] == "" {
+			continue
+		}
+
+		var mappedNpc MappedMultilangNpc
+		mappedNpc.AnkamaId = npc.Id
+		mappedNpc.Actions = npc.Actions
+		mappedNpc.Name = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedNpc.Name[lang] = (*langs)[lang].Texts[npc.NameId]
+		}
+
+		mappedNpc.Messages, mappedNpc.UnlinkedReplies = mapNpcDialog(data, langs, &npc)
+
+		mappedNpcs = append(mappedNpcs, mappedNpc)
+	}
+
+	if len(mappedNpcs) == 0 {
+		return nil
+	}
+
+	return mappedNpcs
+}
+
+func MapJobs(data *JSONGameData, langs *map[string]LangDict) []MappedMultilangJob {
+	jobSkills := make(map[int][]JSONGameSkill)
+	for _, skill := range data.skills {
+		jobSkills[skill.ParentJobId] = append(jobSkills[skill.ParentJobId], skill)
+	}
+
+	var mappedJobs []MappedMultilangJob
+	for _, job := range data.jobs {
+		if (*langs)["fr"].Texts[job.NameId] == "" {
+			continue
+		}
+
+		var mappedJob MappedMultilangJob
+		mappedJob.AnkamaId = job.Id
+		mappedJob.IconId = job.IconId
+		mappedJob.Name = make(map[string]string)
+
+		for _, lang := range utils.Languages {
+			mappedJob.Name[lang] = (*langs)[lang].Texts[job.NameId]
+		}
+
+		skills := jobSkills[job.Id]
+		sort.Slice(skills, func(i, j int) bool {
+			return skills[i].Id < skills[j].Id
+		})
+		for _, skill := range skills {
+			mappedSkill := MappedMultilangJobSkill{
+				Id:                     skill.Id,
+				LevelMin:               skill.LevelMin,
+				IsForgemagus:           skill.IsForgemagus,
+				GatheredResourceItemId: skill.GatheredRessourceItem,
+				Name:                   make(map[string]string),
+			}
+			for _, lang := range utils.Languages {
+				mappedSkill.Name[lang] = (*langs)[lang].Texts[skill.NameId]
+			}
+			mappedJob.Skills = append(mappedJob.Skills, mappedSkill)
+		}
+
+		mappedJobs = append(mappedJobs, mappedJob)
+	}
+
+	if len(mappedJobs) == 0 {
+		return nil
+	}
+
+	return mappedJobs
+}
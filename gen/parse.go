@@ -61,6 +61,26 @@ func Parse() {
 
 	out.Write(outBytes)
 
+	// ----
+	log.Println("reporting unhandled criteria...")
+	unhandledCriteria := UnhandledCriteria(gameData.Items)
+	if len(unhandledCriteria) > 0 {
+		log.Println("unhandled criterion codes:", unhandledCriteria)
+	}
+	outUnhandled, err := os.Create("data/UNHANDLED_CRITERIA.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outUnhandled.Close()
+
+	outUnhandledBytes, err := json.MarshalIndent(unhandledCriteria, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outUnhandled.Write(outUnhandledBytes)
+
 	// ----
 	log.Println("mapping mounts...")
 	mappedMounts := MapMounts(gameData, &languageData)
@@ -115,6 +135,186 @@ func Parse() {
 
 	outRecipes.Write(outRecipeBytes)
 
+	// ----
+	log.Println("mapping spells...")
+	mappedSpells := MapSpells(gameData, &languageData)
+	log.Println("saving spells...")
+	outSpells, err := os.Create("data/MAPPED_SPELLS.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outSpells.Close()
+
+	outSpellsBytes, err := json.MarshalIndent(mappedSpells, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outSpells.Write(outSpellsBytes)
+
+	// ----
+	log.Println("mapping breeds...")
+	mappedBreeds := MapBreeds(gameData, &languageData)
+	log.Println("saving breeds...")
+	outBreeds, err := os.Create("data/MAPPED_BREEDS.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outBreeds.Close()
+
+	outBreedsBytes, err := json.MarshalIndent(mappedBreeds, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outBreeds.Write(outBreedsBytes)
+
+	// ----
+	log.Println("mapping monsters...")
+	mappedMonsters := MapMonsters(gameData, &languageData)
+	log.Println("saving monsters...")
+	outMonsters, err := os.Create("data/MAPPED_MONSTERS.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outMonsters.Close()
+
+	outMonstersBytes, err := json.MarshalIndent(mappedMonsters, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outMonsters.Write(outMonstersBytes)
+
+	// ----
+	log.Println("mapping almanax...")
+	mappedAlmanax := MapAlmanax(gameData, &languageData)
+	log.Println("saving almanax...")
+	outAlmanax, err := os.Create("data/MAPPED_ALMANAX.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outAlmanax.Close()
+
+	outAlmanaxBytes, err := json.MarshalIndent(mappedAlmanax, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outAlmanax.Write(outAlmanaxBytes)
+
+	// ----
+	log.Println("mapping idols...")
+	mappedIdols := MapIdols(gameData, &languageData)
+	log.Println("saving idols...")
+	outIdols, err := os.Create("data/MAPPED_IDOLS.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outIdols.Close()
+
+	outIdolsBytes, err := json.MarshalIndent(mappedIdols, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outIdols.Write(outIdolsBytes)
+
+	// ----
+	log.Println("mapping companions...")
+	mappedCompanions := MapCompanions(gameData, &languageData)
+	log.Println("saving companions...")
+	outCompanions, err := os.Create("data/MAPPED_COMPANIONS.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outCompanions.Close()
+
+	outCompanionsBytes, err := json.MarshalIndent(mappedCompanions, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outCompanions.Write(outCompanionsBytes)
+
+	// ----
+	log.Println("mapping areas...")
+	mappedAreas := MapAreas(gameData, &languageData)
+	log.Println("saving areas...")
+	outAreas, err := os.Create("data/MAPPED_AREAS.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outAreas.Close()
+
+	outAreasBytes, err := json.MarshalIndent(mappedAreas, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outAreas.Write(outAreasBytes)
+
+	// ----
+	log.Println("mapping super areas...")
+	mappedSuperAreas := MapSuperAreas(gameData, &languageData)
+	log.Println("saving super areas...")
+	outSuperAreas, err := os.Create("data/MAPPED_SUPER_AREAS.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outSuperAreas.Close()
+
+	outSuperAreasBytes, err := json.MarshalIndent(mappedSuperAreas, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outSuperAreas.Write(outSuperAreasBytes)
+
+	// ----
+	log.Println("mapping npcs...")
+	mappedNpcs := MapNpcs(gameData, &languageData)
+	log.Println("saving npcs...")
+	outNpcs, err := os.Create("data/MAPPED_NPCS.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outNpcs.Close()
+
+	outNpcsBytes, err := json.MarshalIndent(mappedNpcs, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outNpcs.Write(outNpcsBytes)
+
+	// ----
+	log.Println("mapping jobs...")
+	mappedJobs := MapJobs(gameData, &languageData)
+	log.Println("saving jobs...")
+	outJobs, err := os.Create("data/MAPPED_JOBS.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer outJobs.Close()
+
+	outJobsBytes, err := json.MarshalIndent(mappedJobs, "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	outJobs.Write(outJobsBytes)
+
 	err = utils.PersistElements("db/elements.json", "db/item_types.json")
 	if err != nil {
 		log.Fatal(err)
@@ -274,47 +474,10 @@ func ParseEffects(data *JSONGameData, allEffects [][]JSONGameItemPossibleEffect,
 	return mappedAllEffects
 }
 
+// ParseCondition lists all known conditions of a criteria string. Use ParseConditionTree to keep the
+// relations between them.
 func ParseCondition(condition string, langs *map[string]LangDict, data *JSONGameData) []MappedMultilangCondition {
-	if condition == "" || (!strings.Contains(condition, "&") && !strings.Contains(condition, "<") && !strings.Contains(condition, ">")) {
-		return nil
-	}
-
-	condition = strings.ReplaceAll(condition, "\n", "")
-
-	lower := strings.ToLower(condition)
-
-	var outs []MappedMultilangCondition
-
-	var parts []string
-	if strings.Contains(lower, "&") {
-		parts = strings.Split(lower, "&")
-	} else {
-		parts = []string{lower}
-	}
-
-	operators := []string{"<", ">", "=", "!"}
-
-	for _, part := range parts {
-		var out MappedMultilangCondition
-		out.Templated = make(map[string]string)
-
-		foundCond := false
-		for _, operator := range operators { // try every known operator against it
-			if strings.Contains(part, operator) {
-				var outTmp MappedMultilangCondition
-				outTmp.Templated = make(map[string]string)
-				foundConditionElement := ConditionWithOperator(part, operator, langs, &out, data)
-				if foundConditionElement {
-					foundCond = true
-				}
-			}
-		}
-
-		if foundCond {
-			outs = append(outs, out)
-		}
-	}
-
+	outs := FlattenConditionTree(ParseConditionTree(condition, langs, data))
 	if len(outs) == 0 {
 		return nil
 	}
@@ -365,6 +528,98 @@ func ParseRawData() *JSONGameData {
 	breedsChan := make(chan map[int]JSONGameBreed)
 	mountFamilyChan := make(chan map[int]JSONGameMountFamily)
 	npcsChan := make(chan map[int]JSONGameNPC)
+	monstersChan := make(chan map[int]JSONGameMonster)
+	monsterRacesChan := make(chan map[int]JSONGameMonsterRace)
+	almanaxChan := make(chan map[int]JSONGameAlmanaxCalendar)
+	questObjectivesChan := make(chan map[int]JSONGameQuestObjective)
+	idolsChan := make(chan map[int]JSONGameIdol)
+	spellPairsChan := make(chan map[int]JSONGameSpellPair)
+	companionsChan := make(chan map[int]JSONGameCompanion)
+	companionSpellsChan := make(chan map[int]JSONGameCompanionSpell)
+	companionCharsChan := make(chan map[int]JSONGameCompanionCharacteristic)
+	characteristicsChan := make(chan map[int]JSONGameCharacteristic)
+	superAreasChan := make(chan map[int]JSONGameSuperArea)
+	npcMessagesChan := make(chan map[int]JSONGameNpcMessage)
+	jobsChan := make(chan map[int]JSONGameJob)
+	skillsChan := make(chan map[int]JSONGameSkill)
+	questsChan := make(chan map[int]JSONGameQuest)
+	questCategoriesChan := make(chan map[int]JSONGameNamedEntry)
+	emotesChan := make(chan map[int]JSONGameNamedEntry)
+	achievementsChan := make(chan map[int]JSONGameNamedEntry)
+	subAreasChan := make(chan map[int]JSONGameNamedEntry)
+	alignmentSidesChan := make(chan map[int]JSONGameNamedEntry)
+	serverGameTypesChan := make(chan map[int]JSONGameNamedEntry)
+
+	go func() {
+		ParseRawDataPart("quests.json", questsChan)
+	}()
+	go func() {
+		ParseRawDataPart("quest_categories.json", questCategoriesChan)
+	}()
+	go func() {
+		ParseRawDataPart("emoticons.json", emotesChan)
+	}()
+	go func() {
+		ParseRawDataPart("achievements.json", achievementsChan)
+	}()
+	go func() {
+		ParseRawDataPart("sub_areas.json", subAreasChan)
+	}()
+	go func() {
+		ParseRawDataPart("alignment_sides.json", alignmentSidesChan)
+	}()
+	go func() {
+		ParseRawDataPart("server_game_types.json", serverGameTypesChan)
+	}()
+
+	go func() {
+		ParseRawDataPart("jobs.json", jobsChan)
+	}()
+	go func() {
+		ParseRawDataPart("skills.json", skillsChan)
+	}()
+
+	go func() {
+		ParseRawDataPart("npc_messages.json", npcMessagesChan)
+	}()
+
+	go func() {
+		ParseRawDataPart("super_areas.json", superAreasChan)
+	}()
+
+	go func() {
+		ParseRawDataPart("companions.json", companionsChan)
+	}()
+	go func() {
+		ParseRawDataPart("companion_spells.json", companionSpellsChan)
+	}()
+	go func() {
+		ParseRawDataPart("companion_chars.json", companionCharsChan)
+	}()
+	go func() {
+		ParseRawDataPart("characteristics.json", characteristicsChan)
+	}()
+
+	go func() {
+		ParseRawDataPart("idols.json", idolsChan)
+	}()
+	go func() {
+		ParseRawDataPart("spell_pairs.json", spellPairsChan)
+	}()
+
+	go func() {
+		ParseRawDataPart("almanax.json", almanaxChan)
+	}()
+	go func() {
+		ParseRawDataPart("quest_objectives.json", questObjectivesChan)
+	}()
+
+	go func() {
+		ParseRawDataPart("monsters.json", monstersChan)
+	}()
+	go func() {
+		ParseRawDataPart("monster_races.json", monsterRacesChan)
+	}()
 
 	go func() {
 		ParseRawDataPart("npcs.json", npcsChan)
@@ -445,6 +700,69 @@ func ParseRawData() *JSONGameData {
 	data.npcs = <-npcsChan
 	close(npcsChan)
 
+	data.Monsters = <-monstersChan
+	close(monstersChan)
+
+	data.monsterRaces = <-monsterRacesChan
+	close(monsterRacesChan)
+
+	data.almanax = <-almanaxChan
+	close(almanaxChan)
+
+	data.questObjectives = <-questObjectivesChan
+	close(questObjectivesChan)
+
+	data.idols = <-idolsChan
+	close(idolsChan)
+
+	data.spellPairs = <-spellPairsChan
+	close(spellPairsChan)
+
+	data.companions = <-companionsChan
+	close(companionsChan)
+
+	data.companionSpells = <-companionSpellsChan
+	close(companionSpellsChan)
+
+	data.companionChars = <-companionCharsChan
+	close(companionCharsChan)
+
+	data.characteristics = <-characteristicsChan
+	close(characteristicsChan)
+
+	data.superAreas = <-superAreasChan
+	close(superAreasChan)
+
+	data.npcMessages = <-npcMessagesChan
+	close(npcMessagesChan)
+
+	data.jobs = <-jobsChan
+	close(jobsChan)
+
+	data.skills = <-skillsChan
+	close(skillsChan)
+
+	data.quests = <-questsChan
+	close(questsChan)
+
+	data.questCategories = <-questCategoriesChan
+	close(questCategoriesChan)
+
+	data.emotes = <-emotesChan
+	close(emotesChan)
+
+	data.achievements = <-achievementsChan
+	close(achievementsChan)
+
+	data.subAreas = <-subAreasChan
+	close(subAreasChan)
+
+	data.alignmentSides = <-alignmentSidesChan
+	close(alignmentSidesChan)
+
+	data.serverGameTypes = <-serverGameTypesChan
+	close(serverGameTypesChan)
+
 	return &data
 }
 
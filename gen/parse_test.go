@@ -1,8 +1,14 @@
 package gen
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/dofusdude/api/utils"
+	"github.com/emirpasic/gods/maps/treebidimap"
+	gutils "github.com/emirpasic/gods/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -254,3 +260,485 @@ func TestParseNumSpellNameFormatterMissingWhite(t *testing.T) {
 	output, _ := NumSpellFormatter(input, "de", testingData, testingLangs, &diceNum, &diceSide, &value, 0, false, false)
 	assert.Equal(t, "+1 level", output)
 }
+
+func TestEvaluateConditionsCharacteristics(t *testing.T) {
+	conditions := []MappedMultilangCondition{
+		{Element: "cs", Family: ConditionFamilyCharacteristic, Operator: ">", Value: 80},
+		{Element: "pl", Family: ConditionFamilyLevel, Operator: ">", Value: 49},
+	}
+	profile := CharacterProfile{
+		Level:           60,
+		Characteristics: map[string]int{"cs": 75},
+	}
+
+	results, passed := EvaluateConditions(conditions, &profile)
+	assert.False(t, passed)
+	assert.Equal(t, 2, len(results))
+	assert.False(t, results[0].Passed)
+	assert.True(t, results[0].Evaluated)
+	assert.True(t, results[1].Passed)
+}
+
+func TestEvaluateConditionsMissingProfileData(t *testing.T) {
+	conditions := []MappedMultilangCondition{
+		{Element: "po", Family: ConditionFamilyArea, Operator: "!", Value: 12},
+		{Element: "of", Family: ConditionFamilyMount, Operator: "=", Value: 3},
+	}
+
+	results, passed := EvaluateConditions(conditions, &CharacterProfile{})
+	assert.False(t, passed)
+	assert.False(t, results[0].Evaluated)
+	assert.True(t, results[0].Passed)
+	assert.False(t, results[1].Passed)
+}
+
+func TestEvaluateConditionsMount(t *testing.T) {
+	mountId := 3
+	equipped := CharacterProfile{MountId: &mountId}
+
+	result := EvaluateCondition(&MappedMultilangCondition{Element: "pf", Family: ConditionFamilyMount, Operator: "!", Value: 3}, &equipped)
+	assert.True(t, result.Evaluated)
+	assert.False(t, result.Passed)
+
+	result = EvaluateCondition(&MappedMultilangCondition{Element: "pf", Family: ConditionFamilyMount, Operator: "!", Value: 4}, &equipped)
+	assert.True(t, result.Passed)
+
+	result = EvaluateCondition(&MappedMultilangCondition{Element: "pf", Family: ConditionFamilyMount, Operator: "=", Value: 3}, &equipped)
+	assert.True(t, result.Passed)
+
+	// without a mount only "not equipped" conditions pass
+	result = EvaluateCondition(&MappedMultilangCondition{Element: "pf", Family: ConditionFamilyMount, Operator: "!", Value: 3}, &CharacterProfile{})
+	assert.True(t, result.Evaluated)
+	assert.True(t, result.Passed)
+
+	result = EvaluateCondition(&MappedMultilangCondition{Element: "pf", Family: ConditionFamilyMount, Operator: "=", Value: 3}, &CharacterProfile{})
+	assert.True(t, result.Evaluated)
+	assert.False(t, result.Passed)
+}
+
+func TestEvaluateConditionsItemOwned(t *testing.T) {
+	// "po" with an item is not an area, even when the profile has an area with the same id
+	areaId := 12
+	result := EvaluateCondition(&MappedMultilangCondition{Element: "po", Family: ConditionFamilyItemOwned, Operator: "=", Value: 12}, &CharacterProfile{AreaId: &areaId})
+	assert.False(t, result.Evaluated)
+	assert.True(t, result.Passed)
+
+	result = EvaluateCondition(&MappedMultilangCondition{Element: "po", Family: ConditionFamilyArea, Operator: "!", Value: 12}, &CharacterProfile{AreaId: &areaId})
+	assert.True(t, result.Evaluated)
+	assert.False(t, result.Passed)
+}
+
+func TestParseCriteriaOrWithParentheses(t *testing.T) {
+	tree, err := ParseCriteria("(CS>100|CA>100)&PL>50")
+
+	assert.Nil(t, err)
+	assert.Equal(t, CriteriaAnd, tree.Type)
+	assert.Equal(t, 2, len(tree.Children))
+
+	or := tree.Children[0]
+	assert.Equal(t, CriteriaOr, or.Type)
+	assert.Equal(t, "CS", or.Children[0].Code)
+	assert.Equal(t, ">", or.Children[0].Operator)
+	assert.Equal(t, "100", or.Children[0].Value)
+	assert.Equal(t, "CA", or.Children[1].Code)
+
+	assert.Equal(t, CriteriaCondition, tree.Children[1].Type)
+	assert.Equal(t, "PL", tree.Children[1].Code)
+	assert.Equal(t, "50", tree.Children[1].Value)
+}
+
+func TestParseCriteriaAndBindsStronger(t *testing.T) {
+	tree, err := ParseCriteria("CI>300|CC>300&PL>100")
+
+	assert.Nil(t, err)
+	assert.Equal(t, CriteriaOr, tree.Type)
+	assert.Equal(t, 2, len(tree.Children))
+	assert.Equal(t, CriteriaCondition, tree.Children[0].Type)
+	assert.Equal(t, CriteriaAnd, tree.Children[1].Type)
+}
+
+func TestParseCriteriaFlattensSameRelation(t *testing.T) {
+	tree, err := ParseCriteria("PO!5&(PF!6&Pz=1)")
+
+	assert.Nil(t, err)
+	assert.Equal(t, CriteriaAnd, tree.Type)
+	assert.Equal(t, 3, len(tree.Children))
+	assert.Equal(t, "!", tree.Children[1].Operator)
+	assert.Equal(t, "=", tree.Children[2].Operator)
+}
+
+func TestParseCriteriaUnbalanced(t *testing.T) {
+	_, err := ParseCriteria("(CS>100|CA>100&PL>50")
+	assert.NotNil(t, err)
+
+	_, err = ParseCriteria("CS>100)")
+	assert.NotNil(t, err)
+}
+
+// criteriaShape writes the tree like "and(or(CS>100,CA>100),PL>50)" to compare whole trees.
+func criteriaShape(node *CriteriaNode) string {
+	if node == nil {
+		return ""
+	}
+	if node.Type == CriteriaCondition {
+		return node.Code + node.Operator + node.Value
+	}
+	var children []string
+	for _, child := range node.Children {
+		children = append(children, criteriaShape(child))
+	}
+	return node.Type + "(" + strings.Join(children, ",") + ")"
+}
+
+func TestParseCriteriaItemCriteria(t *testing.T) {
+	tests := []struct {
+		criteria string
+		shape    string
+	}{
+		{"PL>149", "PL>149"},
+		{"null", ""},
+		{"(CS>400|CI>400|CA>400|CC>400)&PL>179", "and(or(CS>400,CI>400,CA>400,CC>400),PL>179)"},
+		{"Pj>41,60", "Pj>41,60"},
+		{"PJ=24,100&PL>99", "and(PJ=24,100,PL>99)"},
+		{"Qf=1136", "Qf=1136"},
+		{"Qf!1583&PO!16799", "and(Qf!1583,PO!16799)"},
+		{"((PO!17048&PO!17049)|Qf=1583)&PL>149", "and(or(and(PO!17048,PO!17049),Qf=1583),PL>149)"},
+		{"PL>99&(((Ps=1&Pa>19)|(Ps=2&Pa>19)))", "and(PL>99,or(and(Ps=1,Pa>19),and(Ps=2,Pa>19)))"},
+		{"Sc=1&PX=0", "and(Sc=1,PX=0)"},
+		{"Pz=1\n&PO=8627", "and(Pz=1,PO=8627)"},
+		{"BI", "BI"},
+	}
+
+	for _, test := range tests {
+		tree, err := ParseCriteria(test.criteria)
+		assert.Nil(t, err, test.criteria)
+		assert.Equal(t, test.shape, criteriaShape(tree), test.criteria)
+	}
+}
+
+// conditionTreeShape writes mapped trees like criteriaShape, leaves are "family=reference" or "?unknown".
+func conditionTreeShape(node *MappedMultilangConditionNode) string {
+	if node == nil {
+		return ""
+	}
+	if node.Condition != nil {
+		return fmt.Sprintf("%s%s%d", node.Condition.Family, node.Condition.Operator, *node.Condition.ReferenceId)
+	}
+	if node.Relation == "" {
+		return "?" + node.Unknown
+	}
+	var children []string
+	for i := range node.Children {
+		children = append(children, conditionTreeShape(&node.Children[i]))
+	}
+	return node.Relation + "(" + strings.Join(children, ",") + ")"
+}
+
+func TestParseConditionTreeItemCriteria(t *testing.T) {
+	persisted := utils.PersistedElements
+	defer func() { utils.PersistedElements = persisted }()
+	utils.PersistedElements = utils.PersistentStringKeysMap{
+		Entries: treebidimap.NewWith(gutils.IntComparator, gutils.StringComparator),
+	}
+
+	langs := make(map[string]LangDict)
+	for _, lang := range utils.Languages {
+		langs[lang] = LangDict{
+			Texts: map[int]string{1: "Quest", 2: "Farmer", 3: "Dofus", 10: "Finished %1", 11: "%1 level %2", 12: "Owns %1", 13: "Does not own %1"},
+			NameText: map[string]int{
+				"ui.criterion.questFinished":  10,
+				"ui.criterion.jobMinLevel":    11,
+				"ui.criterion.possessItem":    12,
+				"ui.criterion.notPossessItem": 13,
+			},
+		}
+	}
+	data := JSONGameData{
+		quests: map[int]JSONGameQuest{1583: {Id: 1583, NameId: 1}},
+		jobs:   map[int]JSONGameJob{28: {Id: 28, NameId: 2}},
+		Items:  map[int]JSONGameItem{17048: {Id: 17048, NameId: 3}, 17049: {Id: 17049, NameId: 3}},
+	}
+
+	tests := []struct {
+		criteria string
+		shape    string
+	}{
+		{"Qf=1583", "quest_finished=1583"},
+		{"Pj>28,60", "job>28"},
+		{"((PO!17048&PO!17049)|Qf=1583)&Sc=1", "and(or(and(item_owned!17048,item_owned!17049),quest_finished=1583),?Sc=1)"},
+		{"Sc=1|Pj>28", "or(?Sc=1,?Pj>28)"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.shape, conditionTreeShape(ParseConditionTree(test.criteria, &langs, &data)), test.criteria)
+	}
+}
+
+func TestParseConditionTreeOr(t *testing.T) {
+	tree := ParseConditionTree("(CS>100|CA>100)&PL>50", testingLangs, testingData)
+
+	assert.Equal(t, CriteriaAnd, tree.Relation)
+	assert.Equal(t, CriteriaOr, tree.Children[0].Relation)
+	assert.Equal(t, "Stärke", tree.Children[0].Children[0].Condition.Templated["de"])
+	assert.Equal(t, "Flinkheit", tree.Children[0].Children[1].Condition.Templated["de"])
+
+	conditions := FlattenConditionTree(tree)
+	assert.Equal(t, 3, len(conditions))
+	assert.Equal(t, 50, conditions[2].Value)
+}
+
+func TestEvaluateConditionTreeOr(t *testing.T) {
+	tree := MappedMultilangConditionNode{
+		Relation: CriteriaOr,
+		Children: []MappedMultilangConditionNode{
+			{Condition: &MappedMultilangCondition{Element: "cs", Family: ConditionFamilyCharacteristic, Operator: ">", Value: 100}},
+			{Condition: &MappedMultilangCondition{Element: "ca", Family: ConditionFamilyCharacteristic, Operator: ">", Value: 100}},
+		},
+	}
+	profile := CharacterProfile{Characteristics: map[string]int{"ca": 150}}
+
+	passed, evaluated := EvaluateConditionTree(&tree, &profile)
+	assert.True(t, passed)
+	assert.True(t, evaluated)
+	profile.Characteristics["ca"] = 10
+	passed, evaluated = EvaluateConditionTree(&tree, &profile)
+	assert.False(t, passed)
+	assert.True(t, evaluated)
+}
+
+func TestParseConditionTreeKeepsUnknown(t *testing.T) {
+	tree := ParseConditionTree("(XX>1|YY=2)&ZZ!3", testingLangs, testingData)
+
+	assert.Equal(t, CriteriaAnd, tree.Relation)
+	assert.Equal(t, CriteriaOr, tree.Children[0].Relation)
+	assert.Equal(t, "XX>1", tree.Children[0].Children[0].Unknown)
+	assert.Equal(t, "YY=2", tree.Children[0].Children[1].Unknown)
+	assert.Equal(t, "ZZ!3", tree.Children[1].Unknown)
+	assert.Empty(t, FlattenConditionTree(tree))
+
+	assert.Nil(t, ParseConditionTree("null", testingLangs, testingData))
+}
+
+func TestEvaluateConditionTreeUnknown(t *testing.T) {
+	// (XX>1|CS>100)
+	tree := MappedMultilangConditionNode{
+		Relation: CriteriaOr,
+		Children: []MappedMultilangConditionNode{
+			{Unknown: "XX>1"},
+			{Condition: &MappedMultilangCondition{Element: "cs", Family: ConditionFamilyCharacteristic, Operator: ">", Value: 100}},
+		},
+	}
+	profile := CharacterProfile{Characteristics: map[string]int{"cs": 50}}
+
+	// the unknown side might pass, so the "or" is not decided
+	passed, evaluated := EvaluateConditionTree(&tree, &profile)
+	assert.True(t, passed)
+	assert.False(t, evaluated)
+
+	profile.Characteristics["cs"] = 150
+	passed, evaluated = EvaluateConditionTree(&tree, &profile)
+	assert.True(t, passed)
+	assert.True(t, evaluated)
+
+	// a failing evaluated condition decides an "and"
+	tree.Relation = CriteriaAnd
+	profile.Characteristics["cs"] = 50
+	passed, evaluated = EvaluateConditionTree(&tree, &profile)
+	assert.False(t, passed)
+	assert.True(t, evaluated)
+}
+
+func TestUnhandledCriteria(t *testing.T) {
+	items := map[int]JSONGameItem{
+		1: {Id: 1, Criteria: "CS>100&Xy=3"},
+		2: {Id: 2, Criteria: "(Qf=489|Xy>1)&PJ>2,20"},
+		3: {Id: 3, Criteria: "null"},
+		4: {Id: 4, Criteria: "Pj=2"},
+	}
+
+	unhandled := UnhandledCriteria(items)
+	assert.Equal(t, map[string]int{"xy": 2, "pj": 1}, unhandled)
+}
+
+func TestParseConditionJobLevel(t *testing.T) {
+	condition := ParseCondition("PJ>2,20", testingLangs, testingData)
+
+	assert.Equal(t, 1, len(condition), "condition length")
+	assert.Equal(t, "pj", condition[0].Element)
+	assert.Equal(t, 20, condition[0].Value)
+	assert.Equal(t, 2, *condition[0].ReferenceId)
+}
+
+func TestConditionFromFamilyKeepsRelation(t *testing.T) {
+	persisted := utils.PersistedElements
+	defer func() { utils.PersistedElements = persisted }()
+	utils.PersistedElements = utils.PersistentStringKeysMap{
+		Entries: treebidimap.NewWith(gutils.IntComparator, gutils.StringComparator),
+	}
+
+	langs := make(map[string]LangDict)
+	for _, lang := range utils.Languages {
+		langs[lang] = LangDict{
+			Texts:    map[int]string{1: "Quest " + lang, 2: "Finished %1", 3: "Not finished %1"},
+			NameText: map[string]int{"ui.criterion.questFinished": 2, "ui.criterion.questNotFinished": 3},
+		}
+	}
+	data := JSONGameData{quests: map[int]JSONGameQuest{489: {Id: 489, NameId: 1}}}
+
+	finished := ParseConditionTree("Qf=489", &langs, &data).Condition
+	assert.Equal(t, ConditionFamilyQuestFinished, finished.Family)
+	assert.Equal(t, "Finished Quest de", finished.Templated["de"])
+	assert.Equal(t, 489, *finished.ReferenceId)
+
+	notFinished := ParseConditionTree("Qf!489", &langs, &data).Condition
+	assert.Equal(t, "Not finished Quest en", notFinished.Templated["en"])
+	assert.NotEqual(t, finished.ElementId, notFinished.ElementId)
+
+	// relations without a criterion text stay unknown
+	assert.Equal(t, "Qf>489", ParseConditionTree("Qf>489", &langs, &data).Unknown)
+}
+
+func TestConditionFromFamilyPersistsOnlyResolved(t *testing.T) {
+	persisted := utils.PersistedElements
+	defer func() { utils.PersistedElements = persisted }()
+	utils.PersistedElements = utils.PersistentStringKeysMap{
+		Entries: treebidimap.NewWith(gutils.IntComparator, gutils.StringComparator),
+	}
+
+	langs := make(map[string]LangDict)
+	for _, lang := range utils.Languages {
+		langs[lang] = LangDict{
+			Texts:    map[int]string{1: "Quest " + lang, 2: "Finished %1"},
+			NameText: map[string]int{"ui.criterion.questFinished": 2},
+		}
+	}
+	// the last language misses the criterion text
+	lastLang := utils.Languages[len(utils.Languages)-1]
+	langs[lastLang] = LangDict{Texts: map[int]string{1: "Quest " + lastLang}}
+	data := JSONGameData{quests: map[int]JSONGameQuest{489: {Id: 489, NameId: 1}}}
+
+	assert.Equal(t, "Qf=489", ParseConditionTree("Qf=489", &langs, &data).Unknown)
+	assert.Equal(t, 0, utils.PersistedElements.Entries.Size())
+	assert.Equal(t, 0, utils.PersistedElements.NextId)
+}
+
+func TestConditionFromFamilyJobWithoutLevel(t *testing.T) {
+	persisted := utils.PersistedElements
+	defer func() { utils.PersistedElements = persisted }()
+	utils.PersistedElements = utils.PersistentStringKeysMap{
+		Entries: treebidimap.NewWith(gutils.IntComparator, gutils.StringComparator),
+	}
+
+	langs := make(map[string]LangDict)
+	for _, lang := range utils.Languages {
+		langs[lang] = LangDict{
+			Texts:    map[int]string{1: "Farmer", 2: "%1 level %2"},
+			NameText: map[string]int{"ui.criterion.jobLevel": 2},
+		}
+	}
+	data := JSONGameData{jobs: map[int]JSONGameJob{28: {Id: 28, NameId: 1}}}
+
+	assert.Equal(t, "Farmer level 20", ParseConditionTree("Pj=28,20", &langs, &data).Condition.Templated["fr"])
+	// without a level the text would keep the placeholder
+	assert.Equal(t, "Pj=28", ParseConditionTree("Pj=28", &langs, &data).Unknown)
+}
+
+func TestAlmanaxCalendarDate(t *testing.T) {
+	month, day, ok := AlmanaxCalendarDate(60)
+	assert.True(t, ok)
+	assert.Equal(t, time.February, month)
+	assert.Equal(t, 29, day)
+
+	month, day, ok = AlmanaxCalendarDate(61)
+	assert.True(t, ok)
+	assert.Equal(t, time.March, month)
+	assert.Equal(t, 1, day)
+
+	month, day, ok = AlmanaxCalendarDate(366)
+	assert.True(t, ok)
+	assert.Equal(t, time.December, month)
+	assert.Equal(t, 31, day)
+
+	_, _, ok = AlmanaxCalendarDate(367)
+	assert.False(t, ok)
+}
+
+func TestMapAlmanaxOfferings(t *testing.T) {
+	langs := make(map[string]LangDict)
+	for _, lang := range utils.Languages {
+		langs[lang] = LangDict{Texts: map[int]string{1: "Almanax", 2: "Quêtes"}}
+	}
+	data := JSONGameData{
+		almanax: map[int]JSONGameAlmanaxCalendar{
+			60:  {Id: 60, NpcId: 7},
+			366: {Id: 366, NpcId: 8},
+		},
+		questCategories: map[int]JSONGameNamedEntry{
+			almanaxQuestCategoryId: {Id: almanaxQuestCategoryId, NameId: 1},
+			5:                      {Id: 5, NameId: 2},
+		},
+		quests: map[int]JSONGameQuest{
+			100: {Id: 100, CategoryId: almanaxQuestCategoryId, StepIds: []int{10}},
+			200: {Id: 200, CategoryId: 5, StepIds: []int{20}},
+		},
+		questObjectives: map[int]JSONGameQuestObjective{
+			// another quest category bringing an item to the same npc
+			1: {Id: 1, StepId: 20, TypeId: questObjectiveBringItemToNpc, Parameters: JSONGameQuestObjectiveParameters{Parameter0: 7, Parameter1: 999, Parameter2: 1}},
+			3: {Id: 3, StepId: 10, TypeId: questObjectiveBringItemToNpc, Parameters: JSONGameQuestObjectiveParameters{Parameter0: 7, Parameter1: 421, Parameter2: 4}},
+			4: {Id: 4, StepId: 10, TypeId: questObjectiveBringItemToNpc, Parameters: JSONGameQuestObjectiveParameters{Parameter0: 7, Parameter1: 422, Parameter2: 2}},
+		},
+	}
+
+	days := make(map[int]MappedMultilangAlmanax)
+	for _, day := range MapAlmanax(&data, &langs) {
+		days[day.Id] = day
+	}
+
+	assert.Equal(t, 2, days[60].Month)
+	assert.Equal(t, 29, days[60].Day)
+	assert.True(t, days[60].HasOffering)
+	assert.Equal(t, MappedMultilangAlmanaxOffering{ItemId: 421, Quantity: 4}, days[60].Offering)
+
+	assert.Equal(t, 12, days[366].Month)
+	assert.Equal(t, 31, days[366].Day)
+	assert.False(t, days[366].HasOffering)
+}
+
+func TestMapNpcDialog(t *testing.T) {
+	langs := make(map[string]LangDict)
+	for _, lang := range utils.Languages {
+		langs[lang] = LangDict{Texts: map[int]string{100: "Hello", 200: "Bye", 300: "Who are you?"}}
+	}
+	data := JSONGameData{npcMessages: map[int]JSONGameNpcMessage{
+		300: {Id: 300, MessageId: 100},
+	}}
+	npc := JSONGameNPC{
+		DialogMessages: [][]int{{1, 300}},
+		// the reply text 300 is also an npc message id, replies must not resolve through the npc messages
+		DialogReplies: [][]int{{11, 300, 1}, {12, 200}},
+	}
+
+	messages, unlinked := mapNpcDialog(&data, &langs, &npc)
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, "Hello", messages[0].Text["en"])
+	assert.Equal(t, 1, len(messages[0].Replies))
+	assert.Equal(t, 11, messages[0].Replies[0].Id)
+	assert.Equal(t, "Who are you?", messages[0].Replies[0].Text["en"])
+
+	assert.Equal(t, 1, len(unlinked))
+	assert.Equal(t, "Bye", unlinked[0].Text["fr"])
+}
+
+func TestCompanionUnlockItems(t *testing.T) {
+	data := JSONGameData{
+		companions: map[int]JSONGameCompanion{1: {Id: 1}, 2: {Id: 2}},
+		Items: map[int]JSONGameItem{
+			13200: {Id: 13200, TypeId: itemTypeCompanion, PossibleEffects: []JSONGameItemPossibleEffect{{MinimumValue: 1}}},
+			13300: {Id: 13300, TypeId: itemTypeCompanion, PossibleEffects: []JSONGameItemPossibleEffect{{MinimumValue: 1}}},
+			13201: {Id: 13201, TypeId: 1, PossibleEffects: []JSONGameItemPossibleEffect{{MinimumValue: 2}}},
+			13202: {Id: 13202, TypeId: itemTypeCompanion, PossibleEffects: []JSONGameItemPossibleEffect{{MinimumValue: 99}}},
+		},
+	}
+
+	assert.Equal(t, map[int]int{1: 13200}, companionUnlockItems(&data))
+}
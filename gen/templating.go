@@ -94,12 +94,8 @@ func ElementFromCode(code string) int {
 		return 335357 // Anderes Gebiet als: %1
 	case "pf":
 		return 644231 // Nicht ausgerüstetes %1-Reittier
-	//case "": // Ps=1
-	//	return 644230 // Ausgerüstetes %1-Reittier
 	case "pa":
 		return 66566 // Gesinunngsstufe
-	//case "":
-	//	return 637203 // Kein ausgerüstetes %1-Reittier haben
 	case "of":
 		return 637212 // Ein ausgerüstetes %1-Reittier haben
 	case "pz":
@@ -109,13 +105,280 @@ func ElementFromCode(code string) int {
 	return -1
 }
 
+// ElementFromCodeAndOperator extends ElementFromCode with the mount texts that depend on the operator.
+func ElementFromCodeAndOperator(code string, operator string) int {
+	code = strings.ToLower(code)
+
+	switch {
+	case code == "pf" && operator == "=":
+		return 644230 // Ausgerüstetes %1-Reittier
+	case code == "of" && operator == "!":
+		return 637203 // Kein ausgerüstetes %1-Reittier haben
+	}
+
+	return ElementFromCode(code)
+}
+
+// ConditionFamilyFromCode resolves the family of the codes that ElementFromCode knows.
+func ConditionFamilyFromCode(code string) string {
+	switch strings.ToLower(code) {
+	case "cs", "ci", "cv", "ca", "cc", "cw", "cm", "cp":
+		return ConditionFamilyCharacteristic
+	case "pk":
+		return ConditionFamilySetBonus
+	case "pl":
+		return ConditionFamilyLevel
+	case "po":
+		return ConditionFamilyArea
+	case "pf", "of":
+		return ConditionFamilyMount
+	case "pa":
+		return ConditionFamilyAlignmentLevel
+	case "pz":
+		return ConditionFamilySubscription
+	}
+
+	return ""
+}
+
+type criterionFamily struct {
+	family string
+	// texts maps the operator to the name of the ui text, %1 is the referenced entity and %2 the level if there is one
+	texts map[string]string
+	name  func(value string, lang string, langs *map[string]LangDict, data *JSONGameData) (string, int, bool)
+	// requiresLevel is set when the texts need the level after the comma in the value
+	requiresLevel bool
+}
+
+func namedEntry(entries func(data *JSONGameData) map[int]JSONGameNamedEntry) func(string, string, *map[string]LangDict, *JSONGameData) (string, int, bool) {
+	return func(value string, lang string, langs *map[string]LangDict, data *JSONGameData) (string, int, bool) {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return "", 0, false
+		}
+		entry, ok := entries(data)[id]
+		if !ok {
+			return "", 0, false
+		}
+		return (*langs)[lang].Texts[entry.NameId], id, true
+	}
+}
+
+func questName(value string, lang string, langs *map[string]LangDict, data *JSONGameData) (string, int, bool) {
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return "", 0, false
+	}
+	quest, ok := data.quests[id]
+	if !ok {
+		return "", 0, false
+	}
+	return (*langs)[lang].Texts[quest.NameId], id, true
+}
+
+// criterionFamilies covers the criteria that reference other game data. Their templated text is the
+// translated criterion text of the operator with the name of the referenced entity.
+var criterionFamilies = map[string]criterionFamily{
+	"qa": {
+		family: ConditionFamilyQuestActive,
+		texts:  map[string]string{"=": "ui.criterion.questActive", "!": "ui.criterion.questNotActive"},
+		name:   questName,
+	},
+	"qc": {
+		family: ConditionFamilyQuestStartable,
+		texts:  map[string]string{"=": "ui.criterion.questStartable", "!": "ui.criterion.questNotStartable"},
+		name:   questName,
+	},
+	"qf": {
+		family: ConditionFamilyQuestFinished,
+		texts:  map[string]string{"=": "ui.criterion.questFinished", "!": "ui.criterion.questNotFinished"},
+		name:   questName,
+	},
+	"pe": {
+		family: ConditionFamilyEmote,
+		texts:  map[string]string{"=": "ui.criterion.emote", "!": "ui.criterion.notEmote"},
+		name:   namedEntry(func(data *JSONGameData) map[int]JSONGameNamedEntry { return data.emotes }),
+	},
+	"oa": {
+		family: ConditionFamilyAchievement,
+		texts:  map[string]string{"=": "ui.criterion.achievement", "!": "ui.criterion.notAchievement"},
+		name:   namedEntry(func(data *JSONGameData) map[int]JSONGameNamedEntry { return data.achievements }),
+	},
+	"pb": {
+		family: ConditionFamilySubarea,
+		texts:  map[string]string{"=": "ui.criterion.subarea", "!": "ui.criterion.notSubarea"},
+		name:   namedEntry(func(data *JSONGameData) map[int]JSONGameNamedEntry { return data.subAreas }),
+	},
+	"ps": {
+		family: ConditionFamilyAlignmentSide,
+		texts:  map[string]string{"=": "ui.criterion.alignment", "!": "ui.criterion.notAlignment"},
+		name:   namedEntry(func(data *JSONGameData) map[int]JSONGameNamedEntry { return data.alignmentSides }),
+	},
+	"sg": {
+		family: ConditionFamilyServerType,
+		texts:  map[string]string{"=": "ui.criterion.serverType", "!": "ui.criterion.notServerType"},
+		name:   namedEntry(func(data *JSONGameData) map[int]JSONGameNamedEntry { return data.serverGameTypes }),
+	},
+	"pg": {
+		family: ConditionFamilyClass,
+		texts:  map[string]string{"=": "ui.criterion.breed", "!": "ui.criterion.notBreed"},
+		name: func(value string, lang string, langs *map[string]LangDict, data *JSONGameData) (string, int, bool) {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				return "", 0, false
+			}
+			breed, ok := data.classes[id]
+			if !ok {
+				return "", 0, false
+			}
+			return (*langs)[lang].Texts[breed.ShortNameId], id, true
+		},
+	},
+	"pj": {
+		family:        ConditionFamilyJob,
+		texts:         map[string]string{"=": "ui.criterion.jobLevel", ">": "ui.criterion.jobMinLevel", "<": "ui.criterion.jobMaxLevel"},
+		requiresLevel: true,
+		name: func(value string, lang string, langs *map[string]LangDict, data *JSONGameData) (string, int, bool) {
+			// the value holds the job and optionally the level, for example "2,20"
+			jobValue, _, _ := strings.Cut(value, ",")
+			id, err := strconv.Atoi(jobValue)
+			if err != nil {
+				return "", 0, false
+			}
+			job, ok := data.jobs[id]
+			if !ok {
+				return "", 0, false
+			}
+			return (*langs)[lang].Texts[job.NameId], id, true
+		},
+	},
+	"pm": {
+		family: ConditionFamilyMap,
+		texts:  map[string]string{"=": "ui.criterion.map", "!": "ui.criterion.notMap"},
+		name: func(value string, lang string, langs *map[string]LangDict, data *JSONGameData) (string, int, bool) {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				return "", 0, false
+			}
+			return value, id, true // maps have no names
+		},
+	},
+	"po": {
+		family: ConditionFamilyItemOwned,
+		texts:  map[string]string{"=": "ui.criterion.possessItem", "!": "ui.criterion.notPossessItem"},
+		name: func(value string, lang string, langs *map[string]LangDict, data *JSONGameData) (string, int, bool) {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				return "", 0, false
+			}
+			item, ok := data.Items[id]
+			if !ok {
+				return "", 0, false
+			}
+			return (*langs)[lang].Texts[item.NameId], id, true
+		},
+	},
+}
+
+// IsCriterionHandled reports whether a condition with this code and value can be mapped.
+func IsCriterionHandled(code string, value string) bool {
+	code = strings.ToLower(code)
+	if family, isFamily := criterionFamilies[code]; isFamily {
+		return !family.requiresLevel || strings.Contains(value, ",")
+	}
+	return ElementFromCode(code) != -1
+}
+
+// uiText looks up a ui text by its name, like "ui.criterion.questFinished".
+func uiText(lang string, name string, langs *map[string]LangDict) (string, bool) {
+	textId, ok := (*langs)[lang].NameText[name]
+	if !ok {
+		return "", false
+	}
+	text, ok := (*langs)[lang].Texts[textId]
+	return text, ok
+}
+
+func conditionFromFamily(code string, value string, operator string, family criterionFamily, langs *map[string]LangDict, out *MappedMultilangCondition, data *JSONGameData) bool {
+	textName, ok := family.texts[operator]
+	if !ok {
+		return false
+	}
+
+	_, level, hasLevel := strings.Cut(value, ",")
+	if family.requiresLevel && !hasLevel {
+		return false
+	}
+	templated := make(map[string]string)
+	var referenceId int
+	for _, lang := range utils.Languages {
+		name, id, ok := family.name(value, lang, langs, data)
+		if !ok {
+			return false
+		}
+		langStr, ok := uiText(lang, textName, langs)
+		if !ok {
+			return false
+		}
+
+		langStr = strings.ReplaceAll(langStr, "%1", name)
+		langStr = strings.ReplaceAll(langStr, "%2", level)
+		templated[lang] = langStr
+		referenceId = id
+	}
+
+	// only persist the element when every language resolved, dropped conditions must not take an id
+	englishText, _ := uiText("en", textName, langs)
+	keySanitized := utils.DeleteReplacer(englishText)
+	key, foundKey := utils.PersistedElements.Entries.GetKey(keySanitized)
+	if foundKey {
+		out.ElementId = key.(int)
+	} else {
+		out.ElementId = utils.PersistedElements.NextId
+		utils.PersistedElements.Entries.Put(utils.PersistedElements.NextId, keySanitized)
+		utils.PersistedElements.NextId++
+	}
+
+	for lang, langStr := range templated {
+		out.Templated[lang] = langStr
+	}
+	out.ReferenceId = &referenceId
+	out.Element = code
+	out.Family = family.family
+	out.Operator = operator
+	if hasLevel {
+		out.Value, _ = strconv.Atoi(level)
+	} else {
+		out.Value, _ = strconv.Atoi(value)
+	}
+	return true
+}
+
 func ConditionWithOperator(input string, operator string, langs *map[string]LangDict, out *MappedMultilangCondition, data *JSONGameData) bool {
-	partSplit := strings.Split(input, operator)
-	rawElement := ElementFromCode(partSplit[0])
+	partSplit := strings.SplitN(input, operator, 2)
+	if len(partSplit) != 2 {
+		return false
+	}
+	code := strings.ToLower(partSplit[0])
+
+	if family, ok := criterionFamilies[code]; ok {
+		if code != "po" {
+			return conditionFromFamily(code, partSplit[1], operator, family, langs, out, data)
+		}
+		// "po" is shared with the area condition, the value decides which one is meant
+		value, _ := strconv.Atoi(partSplit[1])
+		if _, isArea := data.areas[value]; !isArea && conditionFromFamily(code, partSplit[1], operator, family, langs, out, data) {
+			return true
+		}
+		out.ReferenceId = nil
+	}
+
+	rawElement := ElementFromCodeAndOperator(partSplit[0], operator)
 	if rawElement == -1 {
 		return false
 	}
 	out.Element = strings.ToLower(partSplit[0])
+	out.Family = ConditionFamilyFromCode(out.Element)
 	out.Value, _ = strconv.Atoi(partSplit[1])
 	for _, lang := range utils.Languages {
 		langStr := (*langs)[lang].Texts[rawElement]
@@ -143,9 +406,12 @@ func ConditionWithOperator(input string, operator string, langs *map[string]Lang
 			break
 		case 335357: // anderes gebiet als %1
 			langStr = strings.ReplaceAll(langStr, "%1", (*langs)[lang].Texts[data.areas[out.Value].NameId])
+			if _, ok := data.areas[out.Value]; ok {
+				areaId := out.Value
+				out.AreaId = &areaId
+			}
 			break
-		case 637212: // reittier %1
-		case 644231:
+		case 637212, 637203, 644230, 644231: // reittier %1
 			langStr = strings.ReplaceAll(langStr, "%1", (*langs)[lang].Texts[data.Mounts[out.Value].NameId])
 			break
 		}
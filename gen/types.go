@@ -1,24 +1,58 @@
 package gen
 
 type SearchIndexedItem struct {
-	Id          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	SuperType   string `json:"super_type"`
-	TypeName    string `json:"type_name"`
-	Level       int    `json:"level"`
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	SuperType    string `json:"super_type"`
+	TypeName     string `json:"type_name"`
+	Level        int    `json:"level"`
+	Pods         int    `json:"pods"`
+	TypeId       int    `json:"type_id"`
+	SuperTypeId  int    `json:"super_type_id"`
+	HasParentSet bool   `json:"has_parent_set"`
+	HasRecipe    bool   `json:"has_recipe"`
+
+	Effects          []string `json:"effects"` // lowercase effect type names
+	EffectElementIds []int    `json:"effect_element_ids"`
 }
 
 type SearchIndexedMount struct {
 	Id         int    `json:"id"`
 	Name       string `json:"name"`
 	FamilyName string `json:"family_name"`
+	FamilyId   int    `json:"family_id"`
+
+	Effects          []string `json:"effects"`
+	EffectElementIds []int    `json:"effect_element_ids"`
+}
+
+type SearchIndexedCompanion struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
 }
 
 type SearchIndexedSet struct {
 	Id    int    `json:"id"`
 	Name  string `json:"name"`
 	Level int    `json:"highest_equipment_level"`
+
+	Effects          []string `json:"effects"`
+	EffectElementIds []int    `json:"effect_element_ids"`
+}
+
+type SearchIndexedSpell struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	TypeName    string `json:"type_name"`
+}
+
+type SearchIndexedMonster struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	RaceName string `json:"race_name"`
+	Level    int    `json:"level"`
 }
 
 type EffectConditionDbEntry struct {
@@ -26,17 +60,57 @@ type EffectConditionDbEntry struct {
 	Name string
 }
 
+// Condition families tell the evaluator what a condition checks, independent of the raw criterion code.
+// The same code can belong to different families, "po" is an area or an owned item depending on its value.
+const (
+	ConditionFamilyCharacteristic = "characteristic"
+	ConditionFamilyLevel          = "level"
+	ConditionFamilySubscription   = "subscription"
+	ConditionFamilyAlignmentLevel = "alignment_level"
+	ConditionFamilySetBonus       = "set_bonus"
+	ConditionFamilyArea           = "area"
+	ConditionFamilyMount          = "mount"
+	ConditionFamilyQuestActive    = "quest_active"
+	ConditionFamilyQuestStartable = "quest_startable"
+	ConditionFamilyQuestFinished  = "quest_finished"
+	ConditionFamilyEmote          = "emote"
+	ConditionFamilyAchievement    = "achievement"
+	ConditionFamilySubarea        = "subarea"
+	ConditionFamilyAlignmentSide  = "alignment_side"
+	ConditionFamilyServerType     = "server_type"
+	ConditionFamilyClass          = "class"
+	ConditionFamilyJob            = "job"
+	ConditionFamilyMap            = "map"
+	ConditionFamilyItemOwned      = "item_owned"
+)
+
 type MappedMultilangCondition struct {
 	Element   string            `json:"element"`
+	Family    string            `json:"family"`
 	ElementId int               `json:"element_id"`
 	Operator  string            `json:"operator"`
 	Value     int               `json:"value"`
 	Templated map[string]string `json:"templated"`
+	AreaId    *int              `json:"area_id,omitempty"`
+	// ReferenceId points to the quest, job, emote, achievement, item or other entity the condition is about.
+	ReferenceId *int `json:"reference_id,omitempty"`
+}
+
+// MappedMultilangConditionNode is either a single condition or an "and"/"or" relation of its children.
+// Comparisons that can not be mapped stay in the tree as leaves with only the raw criterion in Unknown.
+type MappedMultilangConditionNode struct {
+	Relation  string                         `json:"relation,omitempty"`
+	Condition *MappedMultilangCondition      `json:"condition,omitempty"`
+	Unknown   string                         `json:"unknown,omitempty"`
+	Children  []MappedMultilangConditionNode `json:"children,omitempty"`
 }
 
 type MappedMultilangRecipe struct {
 	ResultId int                          `json:"result_id"`
 	Entries  []MappedMultilangRecipeEntry `json:"entries"`
+	JobId    int                          `json:"job_id"`
+	SkillId  int                          `json:"skill_id"`
+	Level    int                          `json:"level"`
 }
 
 type MappedMultilangRecipeEntry struct {
@@ -56,6 +130,9 @@ type MappedMultilangSet struct {
 	ItemIds  []int                     `json:"items"`
 	Effects  [][]MappedMultilangEffect `json:"effects"`
 	Level    int                       `json:"level"`
+
+	// EffectPieces holds the number of worn items each entry of Effects applies to.
+	EffectPieces []int `json:"effect_pieces"`
 }
 
 type MappedMultilangMount struct {
@@ -66,6 +143,211 @@ type MappedMultilangMount struct {
 	Effects    []MappedMultilangEffect `json:"effects"`
 }
 
+type MappedMultilangSpellType struct {
+	Id        int               `json:"id"`
+	LongName  map[string]string `json:"long_name"`
+	ShortName map[string]string `json:"short_name"`
+}
+
+type MappedMultilangSpell struct {
+	AnkamaId    int                      `json:"ankama_id"`
+	Name        map[string]string        `json:"name"`
+	Description map[string]string        `json:"description"`
+	Type        MappedMultilangSpellType `json:"type"`
+	Order       int                      `json:"order"`
+	IconId      int                      `json:"icon_id"`
+	Image       string                   `json:"image"`
+	SpellLevels []int                    `json:"spell_levels"`
+}
+
+type MappedMultilangBreed struct {
+	AnkamaId            int               `json:"ankama_id"`
+	ShortName           map[string]string `json:"short_name"`
+	LongName            map[string]string `json:"long_name"`
+	Description         map[string]string `json:"description"`
+	GameplayDescription map[string]string `json:"gameplay_description"`
+	SpellIds            []int             `json:"spell_ids"`
+}
+
+type MappedMultilangMonsterRace struct {
+	Id   int               `json:"id"`
+	Name map[string]string `json:"name"`
+}
+
+type MappedMultilangMonsterGrade struct {
+	Grade             int `json:"grade"`
+	Level             int `json:"level"`
+	LifePoints        int `json:"life_points"`
+	ActionPoints      int `json:"action_points"`
+	MovementPoints    int `json:"movement_points"`
+	ApDodge           int `json:"ap_dodge"`
+	MpDodge           int `json:"mp_dodge"`
+	Wisdom            int `json:"wisdom"`
+	Experience        int `json:"experience"`
+	NeutralResistance int `json:"neutral_resistance"`
+	EarthResistance   int `json:"earth_resistance"`
+	FireResistance    int `json:"fire_resistance"`
+	WaterResistance   int `json:"water_resistance"`
+	AirResistance     int `json:"air_resistance"`
+}
+
+type MappedMultilangMonsterDrop struct {
+	ItemId      int       `json:"item_id"`
+	Count       int       `json:"count"`
+	Percentages []float64 `json:"percentages"` // indexed by grade
+	HasCriteria bool      `json:"has_criteria"`
+}
+
+type MappedMultilangMonster struct {
+	AnkamaId       int                           `json:"ankama_id"`
+	Name           map[string]string             `json:"name"`
+	GfxId          int                           `json:"gfx_id"`
+	Image          string                        `json:"image"`
+	Race           MappedMultilangMonsterRace    `json:"race"`
+	Grades         []MappedMultilangMonsterGrade `json:"grades"`
+	Drops          []MappedMultilangMonsterDrop  `json:"drops"`
+	IsBoss         bool                          `json:"is_boss"`
+	IsMiniBoss     bool                          `json:"is_mini_boss"`
+	IsQuestMonster bool                          `json:"is_quest_monster"`
+}
+
+type MappedMultilangAlmanaxOffering struct {
+	ItemId   int `json:"item_id"`
+	Quantity int `json:"quantity"`
+}
+
+type MappedMultilangAlmanax struct {
+	Id               int                            `json:"id"`
+	Month            int                            `json:"month"`
+	Day              int                            `json:"day"`
+	Bonus            map[string]string              `json:"bonus"`
+	BonusDescription map[string]string              `json:"bonus_description"`
+	NpcId            int                            `json:"npc_id"`
+	NpcName          map[string]string              `json:"npc_name"`
+	HasOffering      bool                           `json:"has_offering"`
+	Offering         MappedMultilangAlmanaxOffering `json:"offering"`
+}
+
+type MappedMultilangIdolSpell struct {
+	Id          int               `json:"id"`
+	Name        map[string]string `json:"name"`
+	Description map[string]string `json:"description"`
+	IconId      int               `json:"icon_id"`
+}
+
+type MappedMultilangIdolSynergy struct {
+	IdolId      int     `json:"idol_id"`
+	Coefficient float64 `json:"coefficient"`
+}
+
+type MappedMultilangIdol struct {
+	AnkamaId        int                          `json:"ankama_id"`
+	Name            map[string]string            `json:"name"`
+	Description     map[string]string            `json:"description"`
+	ItemId          int                          `json:"item_id"`
+	IconId          int                          `json:"icon_id"`
+	Image           string                       `json:"image"`
+	CategoryId      int                          `json:"category_id"`
+	Level           int                          `json:"level"`
+	Score           int                          `json:"score"`
+	ExperienceBonus int                          `json:"experience_bonus"`
+	DropBonus       int                          `json:"drop_bonus"`
+	GroupOnly       bool                         `json:"group_only"`
+	Spell           MappedMultilangIdolSpell     `json:"spell"`
+	Synergies       []MappedMultilangIdolSynergy `json:"synergies"`
+}
+
+type MappedMultilangCompanionSpell struct {
+	SpellId      int    `json:"spell_id"`
+	GradeByLevel string `json:"grade_by_level"`
+}
+
+type MappedMultilangCompanionStatStep struct {
+	Level float64 `json:"level"`
+	Value float64 `json:"value"`
+}
+
+type MappedMultilangCompanionCharacteristic struct {
+	Id      int                                `json:"id"`
+	Name    map[string]string                  `json:"name"`
+	Order   int                                `json:"order"`
+	Scaling []MappedMultilangCompanionStatStep `json:"scaling"`
+}
+
+type MappedMultilangCompanion struct {
+	AnkamaId             int                                      `json:"ankama_id"`
+	Name                 map[string]string                        `json:"name"`
+	Description          map[string]string                        `json:"description"`
+	AssetId              int                                      `json:"asset_id"`
+	StartingSpellLevelId int                                      `json:"starting_spell_level_id"`
+	Spells               []MappedMultilangCompanionSpell          `json:"spells"`
+	Characteristics      []MappedMultilangCompanionCharacteristic `json:"characteristics"`
+	HasUnlockItem        bool                                     `json:"has_unlock_item"`
+	UnlockItemId         int                                      `json:"unlock_item_id"`
+}
+
+type MappedMultilangAreaBounds struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type MappedMultilangArea struct {
+	AnkamaId        int                       `json:"ankama_id"`
+	Name            map[string]string         `json:"name"`
+	SuperAreaId     int                       `json:"super_area_id"`
+	ContainHouses   bool                      `json:"contain_houses"`
+	ContainPaddocks bool                      `json:"contain_paddocks"`
+	Bounds          MappedMultilangAreaBounds `json:"bounds"`
+	WorldmapId      int                       `json:"worldmap_id"`
+	HasWorldMap     bool                      `json:"has_world_map"`
+}
+
+type MappedMultilangSuperArea struct {
+	AnkamaId    int               `json:"ankama_id"`
+	Name        map[string]string `json:"name"`
+	WorldmapId  int               `json:"worldmap_id"`
+	HasWorldMap bool              `json:"has_world_map"`
+	AreaIds     []int             `json:"area_ids"`
+}
+
+type MappedMultilangNpcDialogEntry struct {
+	Id   int               `json:"id"`
+	Text map[string]string `json:"text"`
+}
+
+// MappedMultilangNpcDialogMessage is a message of the npc with the replies the player can answer it with.
+type MappedMultilangNpcDialogMessage struct {
+	Id      int                             `json:"id"`
+	Text    map[string]string               `json:"text"`
+	Replies []MappedMultilangNpcDialogEntry `json:"replies"`
+}
+
+type MappedMultilangNpc struct {
+	AnkamaId int                               `json:"ankama_id"`
+	Name     map[string]string                 `json:"name"`
+	Messages []MappedMultilangNpcDialogMessage `json:"messages"`
+	// UnlinkedReplies are the replies that the game data does not link to one of the messages.
+	UnlinkedReplies []MappedMultilangNpcDialogEntry `json:"unlinked_replies"`
+	Actions         []int                           `json:"actions"`
+}
+
+type MappedMultilangJobSkill struct {
+	Id                     int               `json:"id"`
+	Name                   map[string]string `json:"name"`
+	LevelMin               int               `json:"level_min"`
+	IsForgemagus           bool              `json:"is_forgemagus"`
+	GatheredResourceItemId int               `json:"gathered_resource_item_id"`
+}
+
+type MappedMultilangJob struct {
+	AnkamaId int                       `json:"ankama_id"`
+	Name     map[string]string         `json:"name"`
+	IconId   int                       `json:"icon_id"`
+	Skills   []MappedMultilangJobSkill `json:"skills"`
+}
+
 type MappedMultilangCharacteristic struct {
 	Value map[string]string `json:"value"`
 	Name  map[string]string `json:"name"`
@@ -97,6 +379,7 @@ type MappedMultilangItem struct {
 	Name                   map[string]string               `json:"name"`
 	Image                  string                          `json:"image"`
 	Conditions             []MappedMultilangCondition      `json:"conditions"`
+	ConditionsTree         *MappedMultilangConditionNode   `json:"conditions_tree"`
 	Level                  int                             `json:"level"`
 	UsedInRecipes          []int                           `json:"used_in_recipes"`
 	Characteristics        []MappedMultilangCharacteristic `json:"characteristics"`
@@ -184,6 +467,17 @@ type JSONGameAreaBounds struct {
 	Height int `json:"height"`
 }
 
+type JSONGameSuperArea struct {
+	Id          int  `json:"id"`
+	NameId      int  `json:"nameId"`
+	WorldmapId  int  `json:"worldmapId"`
+	HasWorldMap bool `json:"hasWorldMap"`
+}
+
+func (i JSONGameSuperArea) GetID() int {
+	return i.Id
+}
+
 type JSONGameArea struct {
 	Id              int                `json:"id"`
 	NameId          int                `json:"nameId"`
@@ -285,10 +579,12 @@ func (i JSONGameItem) GetID() int {
 }
 
 type JSONGameBreed struct {
-	Id            int `json:"id"`
-	ShortNameId   int `json:"shortNameId"`
-	LongNameId    int `json:"longNameId"`
-	DescriptionId int `json:"descriptionId"`
+	Id                    int   `json:"id"`
+	ShortNameId           int   `json:"shortNameId"`
+	LongNameId            int   `json:"longNameId"`
+	DescriptionId         int   `json:"descriptionId"`
+	GameplayDescriptionId int   `json:"gameplayDescriptionId"`
+	BreedSpellsId         []int `json:"breedSpellsId"`
 }
 
 func (i JSONGameBreed) GetID() int {
@@ -316,6 +612,235 @@ func (i JSONGameMountFamily) GetID() int {
 	return i.Id
 }
 
+type JSONGameMonsterGrade struct {
+	Grade             int `json:"grade"`
+	MonsterId         int `json:"monsterId"`
+	Level             int `json:"level"`
+	LifePoints        int `json:"lifePoints"`
+	ActionPoints      int `json:"actionPoints"`
+	MovementPoints    int `json:"movementPoints"`
+	PaDodge           int `json:"paDodge"`
+	PmDodge           int `json:"pmDodge"`
+	Wisdom            int `json:"wisdom"`
+	GradeXp           int `json:"gradeXp"`
+	EarthResistance   int `json:"earthResistance"`
+	AirResistance     int `json:"airResistance"`
+	FireResistance    int `json:"fireResistance"`
+	WaterResistance   int `json:"waterResistance"`
+	NeutralResistance int `json:"neutralResistance"`
+}
+
+type JSONGameMonsterDrop struct {
+	DropId               int     `json:"dropId"`
+	MonsterId            int     `json:"monsterId"`
+	ObjectId             int     `json:"objectId"`
+	PercentDropForGrade1 float64 `json:"percentDropForGrade1"`
+	PercentDropForGrade2 float64 `json:"percentDropForGrade2"`
+	PercentDropForGrade3 float64 `json:"percentDropForGrade3"`
+	PercentDropForGrade4 float64 `json:"percentDropForGrade4"`
+	PercentDropForGrade5 float64 `json:"percentDropForGrade5"`
+	Count                int     `json:"count"`
+	HasCriteria          bool    `json:"hasCriteria"`
+}
+
+type JSONGameMonster struct {
+	Id             int                    `json:"id"`
+	NameId         int                    `json:"nameId"`
+	GfxId          int                    `json:"gfxId"`
+	Race           int                    `json:"race"`
+	Grades         []JSONGameMonsterGrade `json:"grades"`
+	Drops          []JSONGameMonsterDrop  `json:"drops"`
+	IsBoss         bool                   `json:"isBoss"`
+	IsMiniBoss     bool                   `json:"isMiniBoss"`
+	IsQuestMonster bool                   `json:"isQuestMonster"`
+}
+
+func (i JSONGameMonster) GetID() int {
+	return i.Id
+}
+
+type JSONGameMonsterRace struct {
+	Id          int `json:"id"`
+	SuperRaceId int `json:"superRaceId"`
+	NameId      int `json:"nameId"`
+}
+
+func (i JSONGameMonsterRace) GetID() int {
+	return i.Id
+}
+
+type JSONGameAlmanaxCalendar struct {
+	Id         int   `json:"id"`
+	NameId     int   `json:"nameId"`
+	DescId     int   `json:"descId"`
+	NpcId      int   `json:"npcId"`
+	BonusesIds []int `json:"bonusesIds"`
+}
+
+func (i JSONGameAlmanaxCalendar) GetID() int {
+	return i.Id
+}
+
+type JSONGameQuestObjectiveParameters struct {
+	NumParams   int  `json:"numParams"`
+	Parameter0  int  `json:"parameter0"`
+	Parameter1  int  `json:"parameter1"`
+	Parameter2  int  `json:"parameter2"`
+	Parameter3  int  `json:"parameter3"`
+	Parameter4  int  `json:"parameter4"`
+	DungeonOnly bool `json:"dungeonOnly"`
+}
+
+type JSONGameQuestObjective struct {
+	Id         int                              `json:"id"`
+	StepId     int                              `json:"stepId"`
+	TypeId     int                              `json:"typeId"`
+	Parameters JSONGameQuestObjectiveParameters `json:"parameters"`
+}
+
+func (i JSONGameQuestObjective) GetID() int {
+	return i.Id
+}
+
+type JSONGameIdol struct {
+	Id                int       `json:"id"`
+	Description       string    `json:"description"`
+	CategoryId        int       `json:"categoryId"`
+	ItemId            int       `json:"itemId"`
+	GroupOnly         bool      `json:"groupOnly"`
+	SpellPairId       int       `json:"spellPairId"`
+	Score             int       `json:"score"`
+	ExperienceBonus   int       `json:"experienceBonus"`
+	DropBonus         int       `json:"dropBonus"`
+	SynergyIdolsIds   []int     `json:"synergyIdolsIds"`
+	SynergyIdolsCoeff []float64 `json:"synergyIdolsCoeff"`
+}
+
+func (i JSONGameIdol) GetID() int {
+	return i.Id
+}
+
+type JSONGameSpellPair struct {
+	Id            int `json:"id"`
+	NameId        int `json:"nameId"`
+	DescriptionId int `json:"descriptionId"`
+	IconId        int `json:"iconId"`
+}
+
+func (i JSONGameSpellPair) GetID() int {
+	return i.Id
+}
+
+type JSONGameCompanion struct {
+	Id                   int    `json:"id"`
+	NameId               int    `json:"nameId"`
+	Look                 string `json:"look"`
+	WebDisplay           bool   `json:"webDisplay"`
+	DescriptionId        int    `json:"descriptionId"`
+	StartingSpellLevelId int    `json:"startingSpellLevelId"`
+	AssetId              int    `json:"assetId"`
+	Characteristics      []int  `json:"characteristics"`
+	Spells               []int  `json:"spells"`
+	CreatureBoneId       int    `json:"creatureBoneId"`
+	Visibility           string `json:"visibility"`
+}
+
+func (i JSONGameCompanion) GetID() int {
+	return i.Id
+}
+
+type JSONGameCompanionSpell struct {
+	Id           int    `json:"id"`
+	SpellId      int    `json:"spellId"`
+	CompanionId  int    `json:"companionId"`
+	GradeByLevel string `json:"gradeByLevel"`
+}
+
+func (i JSONGameCompanionSpell) GetID() int {
+	return i.Id
+}
+
+type JSONGameCompanionCharacteristic struct {
+	Id                int         `json:"id"`
+	CaracId           int         `json:"caracId"`
+	CompanionId       int         `json:"companionId"`
+	Order             int         `json:"order"`
+	StatPerLevelRange [][]float64 `json:"statPerLevelRange"`
+}
+
+func (i JSONGameCompanionCharacteristic) GetID() int {
+	return i.Id
+}
+
+type JSONGameCharacteristic struct {
+	Id         int    `json:"id"`
+	Keyword    string `json:"keyword"`
+	NameId     int    `json:"nameId"`
+	CategoryId int    `json:"categoryId"`
+	Visible    bool   `json:"visible"`
+	Order      int    `json:"order"`
+}
+
+func (i JSONGameCharacteristic) GetID() int {
+	return i.Id
+}
+
+// JSONGameNamedEntry is used for game data where only the translated name is of interest,
+// like quests, emotes or achievements referenced by item criteria.
+type JSONGameNamedEntry struct {
+	Id     int `json:"id"`
+	NameId int `json:"nameId"`
+}
+
+func (i JSONGameNamedEntry) GetID() int {
+	return i.Id
+}
+
+type JSONGameQuest struct {
+	Id         int   `json:"id"`
+	NameId     int   `json:"nameId"`
+	CategoryId int   `json:"categoryId"`
+	StepIds    []int `json:"stepIds"`
+}
+
+func (i JSONGameQuest) GetID() int {
+	return i.Id
+}
+
+type JSONGameJob struct {
+	Id     int `json:"id"`
+	NameId int `json:"nameId"`
+	IconId int `json:"iconId"`
+}
+
+func (i JSONGameJob) GetID() int {
+	return i.Id
+}
+
+type JSONGameSkill struct {
+	Id                    int   `json:"id"`
+	NameId                int   `json:"nameId"`
+	ParentJobId           int   `json:"parentJobId"`
+	IsForgemagus          bool  `json:"isForgemagus"`
+	GatheredRessourceItem int   `json:"gatheredRessourceItem"`
+	CraftableItemIds      []int `json:"craftableItemIds"`
+	LevelMin              int   `json:"levelMin"`
+}
+
+func (i JSONGameSkill) GetID() int {
+	return i.Id
+}
+
+type JSONGameNpcMessage struct {
+	Id            int      `json:"id"`
+	MessageId     int      `json:"messageId"`
+	MessageParams []string `json:"messageParams"`
+}
+
+func (i JSONGameNpcMessage) GetID() int {
+	return i.Id
+}
+
 type JSONGameNPC struct {
 	Id             int     `json:"id"`
 	NameId         int     `json:"nameId"`
@@ -329,17 +854,38 @@ func (i JSONGameNPC) GetID() int {
 }
 
 type JSONGameData struct {
-	Items        map[int]JSONGameItem
-	Sets         map[int]JSONGameSet
-	ItemTypes    map[int]JSONGameItemType
-	effects      map[int]JSONGameEffect
-	bonuses      map[int]JSONGameBonus
-	Recipes      map[int]JSONGameRecipe
-	spells       map[int]JSONGameSpell
-	spellTypes   map[int]JSONGameSpellType
-	areas        map[int]JSONGameArea
-	Mounts       map[int]JSONGameMount
-	classes      map[int]JSONGameBreed
-	MountFamilys map[int]JSONGameMountFamily
-	npcs         map[int]JSONGameNPC
+	Items           map[int]JSONGameItem
+	Sets            map[int]JSONGameSet
+	ItemTypes       map[int]JSONGameItemType
+	effects         map[int]JSONGameEffect
+	bonuses         map[int]JSONGameBonus
+	Recipes         map[int]JSONGameRecipe
+	spells          map[int]JSONGameSpell
+	spellTypes      map[int]JSONGameSpellType
+	areas           map[int]JSONGameArea
+	Mounts          map[int]JSONGameMount
+	classes         map[int]JSONGameBreed
+	MountFamilys    map[int]JSONGameMountFamily
+	npcs            map[int]JSONGameNPC
+	Monsters        map[int]JSONGameMonster
+	monsterRaces    map[int]JSONGameMonsterRace
+	almanax         map[int]JSONGameAlmanaxCalendar
+	questObjectives map[int]JSONGameQuestObjective
+	idols           map[int]JSONGameIdol
+	spellPairs      map[int]JSONGameSpellPair
+	companions      map[int]JSONGameCompanion
+	companionSpells map[int]JSONGameCompanionSpell
+	companionChars  map[int]JSONGameCompanionCharacteristic
+	characteristics map[int]JSONGameCharacteristic
+	superAreas      map[int]JSONGameSuperArea
+	npcMessages     map[int]JSONGameNpcMessage
+	jobs            map[int]JSONGameJob
+	skills          map[int]JSONGameSkill
+	quests          map[int]JSONGameQuest
+	questCategories map[int]JSONGameNamedEntry
+	emotes          map[int]JSONGameNamedEntry
+	achievements    map[int]JSONGameNamedEntry
+	subAreas        map[int]JSONGameNamedEntry
+	alignmentSides  map[int]JSONGameNamedEntry
+	serverGameTypes map[int]JSONGameNamedEntry
 }
@@ -47,6 +47,16 @@ func AutoUpdate(done chan bool, indexed *bool, version *utils.VersionT, ticker *
 			nowOldSetsTable := fmt.Sprintf("%s-sets", utils.CurrentRedBlueVersionStr(version.MemDb))
 			nowOldMountsTable := fmt.Sprintf("%s-mounts", utils.CurrentRedBlueVersionStr(version.MemDb))
 			nowOldRecipesTable := fmt.Sprintf("%s-recipes", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldSpellsTable := fmt.Sprintf("%s-spells", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldClassesTable := fmt.Sprintf("%s-classes", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldMonstersTable := fmt.Sprintf("%s-monsters", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldAlmanaxTable := fmt.Sprintf("%s-almanax", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldIdolsTable := fmt.Sprintf("%s-idols", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldCompanionsTable := fmt.Sprintf("%s-companions", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldAreasTable := fmt.Sprintf("%s-areas", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldSuperAreasTable := fmt.Sprintf("%s-superareas", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldNpcsTable := fmt.Sprintf("%s-npcs", utils.CurrentRedBlueVersionStr(version.MemDb))
+			nowOldJobsTable := fmt.Sprintf("%s-jobs", utils.CurrentRedBlueVersionStr(version.MemDb))
 
 			version.MemDb = !version.MemDb // atomic version switch
 			log.Println("updated db version")
@@ -68,12 +78,51 @@ func AutoUpdate(done chan bool, indexed *bool, version *utils.VersionT, ticker *
 			if err != nil {
 				log.Fatal(err)
 			}
+			_, err = delOldTxn.DeleteAll(nowOldSpellsTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = delOldTxn.DeleteAll(nowOldClassesTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = delOldTxn.DeleteAll(nowOldMonstersTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = delOldTxn.DeleteAll(nowOldAlmanaxTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = delOldTxn.DeleteAll(nowOldIdolsTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = delOldTxn.DeleteAll(nowOldCompanionsTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = delOldTxn.DeleteAll(nowOldAreasTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = delOldTxn.DeleteAll(nowOldSuperAreasTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = delOldTxn.DeleteAll(nowOldNpcsTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = delOldTxn.DeleteAll(nowOldJobsTable, "id")
+			if err != nil {
+				log.Fatal(err)
+			}
 			delOldTxn.Commit()
 
 			// ----
 			updateSearchIndex <- idx
 
-			client := utils.CreateMeiliClient()
 			nowOldRedBlueVersion := utils.CurrentRedBlueVersionStr(version.Search)
 
 			version.Search = !version.Search // atomic version switch
@@ -83,22 +132,31 @@ func AutoUpdate(done chan bool, indexed *bool, version *utils.VersionT, ticker *
 				nowOldItemIndexUid := fmt.Sprintf("%s-all_items-%s", nowOldRedBlueVersion, lang)
 				nowOldSetIndexUid := fmt.Sprintf("%s-sets-%s", nowOldRedBlueVersion, lang)
 				nowOldMountIndexUid := fmt.Sprintf("%s-mounts-%s", nowOldRedBlueVersion, lang)
+				nowOldSpellIndexUid := fmt.Sprintf("%s-spells-%s", nowOldRedBlueVersion, lang)
+				nowOldMonsterIndexUid := fmt.Sprintf("%s-monsters-%s", nowOldRedBlueVersion, lang)
+				nowOldCompanionIndexUid := fmt.Sprintf("%s-companions-%s", nowOldRedBlueVersion, lang)
 
-				itemDeleteTask, err := client.DeleteIndex(nowOldItemIndexUid)
-				_, err = client.WaitForTask(itemDeleteTask.TaskUID)
-				if err != nil {
+				if err := utils.Search.DeleteIndex(nowOldItemIndexUid); err != nil {
 					log.Fatal(err)
 				}
 
-				setDeletionTask, err := client.DeleteIndex(nowOldSetIndexUid)
-				_, err = client.WaitForTask(setDeletionTask.TaskUID)
-				if err != nil {
+				if err := utils.Search.DeleteIndex(nowOldSetIndexUid); err != nil {
 					log.Fatal(err)
 				}
 
-				mountDeletionTask, err := client.DeleteIndex(nowOldMountIndexUid)
-				_, err = client.WaitForTask(mountDeletionTask.TaskUID)
-				if err != nil {
+				if err := utils.Search.DeleteIndex(nowOldMountIndexUid); err != nil {
+					log.Fatal(err)
+				}
+
+				if err := utils.Search.DeleteIndex(nowOldSpellIndexUid); err != nil {
+					log.Fatal(err)
+				}
+
+				if err := utils.Search.DeleteIndex(nowOldMonsterIndexUid); err != nil {
+					log.Fatal(err)
+				}
+
+				if err := utils.Search.DeleteIndex(nowOldCompanionIndexUid); err != nil {
 					log.Fatal(err)
 				}
 			}
@@ -0,0 +1,461 @@
+package server
+
+import (
+	"fmt"
+	"github.com/dofusdude/api/gen"
+	"github.com/dofusdude/api/utils"
+	"github.com/hashicorp/go-memdb"
+	"sort"
+)
+
+type APISetBonusRequest struct {
+	ItemIds []int `json:"equipment_ids"`
+}
+
+type APISummedEffect struct {
+	MinInt int           `json:"int_minimum"`
+	MaxInt int           `json:"int_maximum"`
+	Type   ApiEffectType `json:"type"`
+}
+
+type APIActiveSetBonus struct {
+	AnkamaId      int         `json:"ankama_id"`
+	Name          string      `json:"name"`
+	EquipmentIds  []int       `json:"equipment_ids"`
+	EquippedCount int         `json:"equipped_count"`
+	Effects       []ApiEffect `json:"effects"`
+}
+
+type APISetBonuses struct {
+	Sets       []APIActiveSetBonus `json:"sets"`
+	Total      []APISummedEffect   `json:"total"`
+	IgnoredIds []int               `json:"ignored_ids"`
+}
+
+// SetBonusTier returns the bonus effects a set grants when the given number of its items is worn.
+// The tiers are not cumulative, each one already holds the complete bonus for that amount of items.
+func SetBonusTier(set *gen.MappedMultilangSet, equippedCount int) []gen.MappedMultilangEffect {
+	for i, pieces := range set.EffectPieces {
+		if pieces == equippedCount && i < len(set.Effects) {
+			return set.Effects[i]
+		}
+	}
+	return nil
+}
+
+// SumEffects adds up the numeric effects by their element id. Meta effects without values are skipped.
+func SumEffects(effects []gen.MappedMultilangEffect, lang string, sums map[int]*APISummedEffect) {
+	for _, effect := range effects {
+		if effect.IsMeta {
+			continue
+		}
+
+		min := effect.Min
+		max := effect.Max
+		if effect.MinMaxIrrelevant <= -1 || max < min {
+			max = min
+		}
+
+		sum, ok := sums[effect.ElementId]
+		if !ok {
+			sum = &APISummedEffect{
+				Type: ApiEffectType{
+					Name:     effect.Type[lang],
+					Id:       effect.ElementId,
+					IsMeta:   effect.IsMeta,
+					IsActive: effect.Active,
+				},
+			}
+			sums[effect.ElementId] = sum
+		}
+		sum.MinInt += min
+		sum.MaxInt += max
+	}
+}
+
+func sortedSummedEffects(sums map[int]*APISummedEffect) []APISummedEffect {
+	res := []APISummedEffect{}
+	for _, sum := range sums {
+		res = append(res, *sum)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Type.Id < res[j].Type.Id
+	})
+	return res
+}
+
+// activeSetBonuses groups the items by their parent set and picks the bonus tier for every set.
+func activeSetBonuses(items []*gen.MappedMultilangItem, lang string, txn *memdb.Txn, sums map[int]*APISummedEffect) []APIActiveSetBonus {
+	setItems := make(map[int][]int)
+	for _, item := range items {
+		if !item.HasParentSet {
+			continue
+		}
+		setItems[item.ParentSet.Id] = append(setItems[item.ParentSet.Id], item.AnkamaId)
+	}
+
+	sets := []APIActiveSetBonus{}
+	for setId, itemIds := range setItems {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "sets"), "id", setId)
+		if err != nil || raw == nil {
+			continue
+		}
+		set := raw.(*gen.MappedMultilangSet)
+		tier := SetBonusTier(set, len(itemIds))
+		sort.Ints(itemIds)
+
+		bonus := APIActiveSetBonus{
+			AnkamaId:      set.AnkamaId,
+			Name:          set.Name[lang],
+			EquipmentIds:  itemIds,
+			EquippedCount: len(itemIds),
+			Effects:       RenderEffects(&tier, lang),
+		}
+		if bonus.Effects == nil {
+			bonus.Effects = []ApiEffect{}
+		}
+		SumEffects(tier, lang, sums)
+		sets = append(sets, bonus)
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].AnkamaId < sets[j].AnkamaId
+	})
+
+	return sets
+}
+
+func CalculateSetBonuses(itemIds []int, lang string, txn *memdb.Txn) APISetBonuses {
+	result := APISetBonuses{
+		IgnoredIds: []int{},
+	}
+
+	seen := make(map[int]bool)
+	var items []*gen.MappedMultilangItem
+	for _, itemId := range itemIds {
+		if seen[itemId] {
+			continue // the same item only counts once for its set
+		}
+		seen[itemId] = true
+
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "equipment"), "id", itemId)
+		if err != nil || raw == nil {
+			result.IgnoredIds = append(result.IgnoredIds, itemId)
+			continue
+		}
+		item := raw.(*gen.MappedMultilangItem)
+		if !item.HasParentSet {
+			result.IgnoredIds = append(result.IgnoredIds, itemId)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	sums := make(map[int]*APISummedEffect)
+	result.Sets = activeSetBonuses(items, lang, txn, sums)
+	result.Total = sortedSummedEffects(sums)
+
+	return result
+}
+
+const (
+	maxCharacterLevel = 200
+
+	ItemSuperTypeAmulet = 1
+	ItemSuperTypeWeapon = 2
+	ItemSuperTypeRing   = 3
+	ItemSuperTypeBelt   = 4
+	ItemSuperTypeBoots  = 5
+	ItemSuperTypeShield = 7
+	ItemSuperTypeHat    = 10
+	ItemSuperTypeCloak  = 11
+	ItemSuperTypePet    = 12
+	ItemSuperTypeDofus  = 13
+)
+
+const (
+	BuildErrorUnknownSlot     = "unknown_slot"
+	BuildErrorUnknownItem     = "unknown_item"
+	BuildErrorWrongSlot       = "wrong_slot"
+	BuildErrorTwoHandedShield = "two_handed_with_shield"
+	BuildErrorDuplicateRing   = "duplicate_ring"
+	BuildErrorDuplicateDofus  = "duplicate_dofus"
+	BuildErrorLevelTooLow     = "level_too_low"
+	BuildErrorRollOutOfRange  = "roll_out_of_range"
+)
+
+// buildSlots maps every equipment slot of a character to the item super type it accepts.
+var buildSlots = map[string]int{
+	"amulet":  ItemSuperTypeAmulet,
+	"weapon":  ItemSuperTypeWeapon,
+	"ring_1":  ItemSuperTypeRing,
+	"ring_2":  ItemSuperTypeRing,
+	"belt":    ItemSuperTypeBelt,
+	"boots":   ItemSuperTypeBoots,
+	"shield":  ItemSuperTypeShield,
+	"hat":     ItemSuperTypeHat,
+	"cloak":   ItemSuperTypeCloak,
+	"pet":     ItemSuperTypePet,
+	"dofus_1": ItemSuperTypeDofus,
+	"dofus_2": ItemSuperTypeDofus,
+	"dofus_3": ItemSuperTypeDofus,
+	"dofus_4": ItemSuperTypeDofus,
+	"dofus_5": ItemSuperTypeDofus,
+	"dofus_6": ItemSuperTypeDofus,
+}
+
+type APIBuildSlot struct {
+	AnkamaId int         `json:"ankama_id"`
+	Rolled   map[int]int `json:"rolled"` // element id -> rolled value
+}
+
+type APIBuildRequest struct {
+	ClassId             int                     `json:"class_id"`
+	Level               int                     `json:"level"`
+	BaseCharacteristics map[int]int             `json:"base_characteristics"` // element id -> points
+	Slots               map[string]APIBuildSlot `json:"slots"`
+}
+
+type APIBuildEquippedItem struct {
+	Slot    string           `json:"slot"`
+	Item    APIListTypedItem `json:"item"`
+	Effects []ApiEffect      `json:"effects"`
+}
+
+type APIBuildError struct {
+	Slot    string `json:"slot,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type APIBuildValidation struct {
+	Valid  bool            `json:"valid"`
+	Errors []APIBuildError `json:"errors"`
+}
+
+type APIBuild struct {
+	Class           APIListClass           `json:"class"`
+	Level           int                    `json:"level"`
+	Equipment       []APIBuildEquippedItem `json:"equipment"`
+	Sets            []APIActiveSetBonus    `json:"sets"`
+	Characteristics []APISummedEffect      `json:"characteristics"`
+	Validation      APIBuildValidation     `json:"validation"`
+}
+
+// ValidateBuildRequest checks the parts of a build request that do not need the database.
+func ValidateBuildRequest(request *APIBuildRequest) bool {
+	if request.Level < 1 || request.Level > maxCharacterLevel || len(request.Slots) == 0 {
+		return false
+	}
+	for _, points := range request.BaseCharacteristics {
+		if points < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// applyRolls replaces the effect ranges of an item with the values the player actually rolled.
+// Rolls outside of the possible range are reported and the original range is kept.
+func applyRolls(slot string, effects []gen.MappedMultilangEffect, rolled map[int]int, errors *[]APIBuildError) []gen.MappedMultilangEffect {
+	if len(rolled) == 0 {
+		return effects
+	}
+
+	res := make([]gen.MappedMultilangEffect, len(effects))
+	copy(res, effects)
+	for i := range res {
+		value, ok := rolled[res[i].ElementId]
+		if !ok || res[i].IsMeta {
+			continue
+		}
+		max := res[i].Max
+		if res[i].MinMaxIrrelevant <= -1 || max < res[i].Min {
+			max = res[i].Min
+		}
+		if value < res[i].Min || value > max {
+			*errors = append(*errors, APIBuildError{
+				Slot:    slot,
+				Code:    BuildErrorRollOutOfRange,
+				Message: fmt.Sprintf("rolled value %d for element %d is not within %d and %d", value, res[i].ElementId, res[i].Min, max),
+			})
+			continue
+		}
+		res[i].Min = value
+		res[i].Max = value
+	}
+	return res
+}
+
+func validateBuildSlots(slots []string, equipped map[string]*gen.MappedMultilangItem, errors *[]APIBuildError) {
+	if weapon, ok := equipped["weapon"]; ok && weapon.TwoHanded {
+		if _, ok := equipped["shield"]; ok {
+			*errors = append(*errors, APIBuildError{
+				Slot:    "shield",
+				Code:    BuildErrorTwoHandedShield,
+				Message: "a shield can not be worn together with a two-handed weapon",
+			})
+		}
+	}
+
+	seen := make(map[int]string)
+	for _, slot := range slots {
+		item, ok := equipped[slot]
+		if !ok {
+			continue
+		}
+		superType := item.Type.SuperTypeId
+		if superType != ItemSuperTypeRing && superType != ItemSuperTypeDofus {
+			continue
+		}
+		if other, ok := seen[item.AnkamaId]; ok {
+			code := BuildErrorDuplicateRing
+			if superType == ItemSuperTypeDofus {
+				code = BuildErrorDuplicateDofus
+			}
+			*errors = append(*errors, APIBuildError{
+				Slot:    slot,
+				Code:    code,
+				Message: fmt.Sprintf("item %d is already equipped in slot %s", item.AnkamaId, other),
+			})
+			continue
+		}
+		seen[item.AnkamaId] = slot
+	}
+}
+
+func sortedBuildSlotNames(slots map[string]APIBuildSlot) []string {
+	names := make([]string, 0, len(slots))
+	for name := range slots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func EvaluateBuild(class *gen.MappedMultilangBreed, request *APIBuildRequest, lang string, txn *memdb.Txn) APIBuild {
+	build := APIBuild{
+		Class:     RenderClassListEntry(class, lang),
+		Level:     request.Level,
+		Equipment: []APIBuildEquippedItem{},
+	}
+	errors := []APIBuildError{}
+	sums := make(map[int]*APISummedEffect)
+
+	equipped := make(map[string]*gen.MappedMultilangItem)
+	var setCandidates []*gen.MappedMultilangItem
+	setSeen := make(map[int]bool)
+	slots := sortedBuildSlotNames(request.Slots)
+	for _, slot := range slots {
+		entry := request.Slots[slot]
+		superType, ok := buildSlots[slot]
+		if !ok {
+			errors = append(errors, APIBuildError{Slot: slot, Code: BuildErrorUnknownSlot, Message: fmt.Sprintf("%s is not an equipment slot", slot)})
+			continue
+		}
+
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "equipment"), "id", entry.AnkamaId)
+		if err != nil || raw == nil {
+			errors = append(errors, APIBuildError{Slot: slot, Code: BuildErrorUnknownItem, Message: fmt.Sprintf("equipment %d does not exist", entry.AnkamaId)})
+			continue
+		}
+		item := raw.(*gen.MappedMultilangItem)
+		if item.Type.SuperTypeId != superType {
+			errors = append(errors, APIBuildError{Slot: slot, Code: BuildErrorWrongSlot, Message: fmt.Sprintf("%s can not be equipped in slot %s", item.Type.Name[lang], slot)})
+			continue
+		}
+		if item.Level > request.Level {
+			errors = append(errors, APIBuildError{Slot: slot, Code: BuildErrorLevelTooLow, Message: fmt.Sprintf("equipment %d requires level %d", item.AnkamaId, item.Level)})
+		}
+		equipped[slot] = item
+
+		effects := applyRolls(slot, item.Effects, entry.Rolled, &errors)
+		renderedEffects := RenderEffects(&effects, lang)
+		if renderedEffects == nil {
+			renderedEffects = []ApiEffect{}
+		}
+		build.Equipment = append(build.Equipment, APIBuildEquippedItem{
+			Slot:    slot,
+			Item:    RenderTypedItemListEntry(item, lang),
+			Effects: renderedEffects,
+		})
+		SumEffects(effects, lang, sums)
+
+		if !setSeen[item.AnkamaId] {
+			setSeen[item.AnkamaId] = true
+			setCandidates = append(setCandidates, item)
+		}
+	}
+
+	validateBuildSlots(slots, equipped, &errors)
+	build.Sets = activeSetBonuses(setCandidates, lang, txn, sums)
+
+	// base characteristics are added last so the localized names of the effects are kept
+	for elementId, points := range request.BaseCharacteristics {
+		if points == 0 {
+			continue
+		}
+		sum, ok := sums[elementId]
+		if !ok {
+			sum = &APISummedEffect{
+				Type: ApiEffectType{
+					Id: elementId,
+				},
+			}
+			if name, found := utils.PersistedElements.Entries.Get(elementId); found {
+				sum.Type.Name = name.(string)
+			}
+			sums[elementId] = sum
+		}
+		sum.MinInt += points
+		sum.MaxInt += points
+	}
+
+	build.Characteristics = sortedSummedEffects(sums)
+	build.Validation = APIBuildValidation{
+		Valid:  len(errors) == 0,
+		Errors: errors,
+	}
+
+	return build
+}
+
+type APIConditionEvaluationRequest struct {
+	AnkamaId int                  `json:"ankama_id"`
+	Profile  gen.CharacterProfile `json:"profile"`
+}
+
+type APIConditionCheck struct {
+	Condition ApiCondition `json:"condition"`
+	Reason    string       `json:"reason"`
+	Passed    bool         `json:"passed"`
+	Evaluated bool         `json:"evaluated"`
+}
+
+type APIConditionEvaluation struct {
+	Item       APIListTypedItem    `json:"item"`
+	Passed     bool                `json:"passed"`
+	Evaluated  bool                `json:"evaluated"` // false when passed depends on conditions that could not be checked
+	Conditions []APIConditionCheck `json:"conditions"`
+}
+
+func RenderConditionEvaluation(item *gen.MappedMultilangItem, profile *gen.CharacterProfile, lang string) APIConditionEvaluation {
+	results, _ := gen.EvaluateConditions(item.Conditions, profile)
+	passed, evaluated := gen.EvaluateConditionTree(item.ConditionsTree, profile)
+	evaluation := APIConditionEvaluation{
+		Item:       RenderTypedItemListEntry(item, lang),
+		Passed:     passed,
+		Evaluated:  evaluated,
+		Conditions: []APIConditionCheck{},
+	}
+
+	for _, result := range results {
+		evaluation.Conditions = append(evaluation.Conditions, APIConditionCheck{
+			Condition: RenderCondition(&result.Condition, lang),
+			Reason:    result.Condition.Templated[lang],
+			Passed:    result.Passed,
+			Evaluated: result.Evaluated,
+		})
+	}
+
+	return evaluation
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"fmt"
+	"github.com/dofusdude/api/gen"
+	"github.com/dofusdude/api/utils"
+	"github.com/hashicorp/go-memdb"
+	"sort"
+)
+
+const (
+	CraftActionOwned       = "owned"
+	CraftActionBuy         = "buy"
+	CraftActionCraft       = "craft"
+	CraftActionUnavailable = "unavailable"
+)
+
+type APICraftCostRequest struct {
+	AnkamaId int             `json:"ankama_id"`
+	Quantity int             `json:"quantity"`
+	Prices   map[int]float64 `json:"prices"`
+	Owned    map[int]int     `json:"owned"`
+}
+
+type APICraftCostNode struct {
+	Item        APIListTypedItem   `json:"item"`
+	Quantity    int                `json:"quantity"`
+	OwnedUsed   int                `json:"owned_used"`
+	Action      string             `json:"action"`
+	UnitPrice   *float64           `json:"unit_price,omitempty"`
+	TotalCost   float64            `json:"total_cost"`
+	Ingredients []APICraftCostNode `json:"ingredients,omitempty"`
+}
+
+type APIShoppingListEntry struct {
+	Item      APIListTypedItem `json:"item"`
+	Quantity  int              `json:"quantity"`
+	UnitPrice float64          `json:"unit_price"`
+	TotalCost float64          `json:"total_cost"`
+}
+
+type APICraftCost struct {
+	Quantity      int                    `json:"quantity"`
+	TotalCost     float64                `json:"total_cost"`
+	Complete      bool                   `json:"complete"`
+	Plan          APICraftCostNode       `json:"plan"`
+	ShoppingList  []APIShoppingListEntry `json:"shopping_list"`
+	MissingPrices []int                  `json:"missing_prices"`
+}
+
+type craftCostEstimator struct {
+	lang    string
+	txn     *memdb.Txn
+	prices  map[int]float64
+	owned   map[int]int
+	missing map[int]bool
+}
+
+func copyOwned(owned map[int]int) map[int]int {
+	res := make(map[int]int, len(owned))
+	for k, v := range owned {
+		res[k] = v
+	}
+	return res
+}
+
+// estimate decides for a single item whether it is cheaper to buy it or to craft it from its recipe.
+// Owned quantities are used first and are shared across the whole tree. The boolean result reports
+// whether every part of the plan could be priced.
+func (e *craftCostEstimator) estimate(item *gen.MappedMultilangItem, quantity int, path map[int]bool) (APICraftCostNode, bool) {
+	node := APICraftCostNode{
+		Item:     RenderTypedItemListEntry(item, e.lang),
+		Quantity: quantity,
+	}
+
+	if owned := e.owned[item.AnkamaId]; owned > 0 {
+		node.OwnedUsed = utils.Min(owned, quantity)
+		e.owned[item.AnkamaId] -= node.OwnedUsed
+	}
+	need := quantity - node.OwnedUsed
+	if need == 0 {
+		node.Action = CraftActionOwned
+		return node, true
+	}
+
+	price, canBuy := e.prices[item.AnkamaId]
+	if canBuy {
+		node.UnitPrice = &price
+	}
+
+	recipe, canCraft := GetRecipeIfExists(item.AnkamaId, e.txn)
+	canCraft = canCraft && len(recipe.Entries) > 0 && !path[item.AnkamaId]
+
+	if canCraft {
+		ownedBefore := copyOwned(e.owned)
+		missingBefore := make(map[int]bool, len(e.missing))
+		for k := range e.missing {
+			missingBefore[k] = true
+		}
+		craftCost := 0.0
+		craftComplete := true
+		var ingredients []APICraftCostNode
+
+		path[item.AnkamaId] = true
+		for _, entry := range recipe.Entries {
+			raw, err := e.txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", entry.ItemId)
+			if err != nil || raw == nil {
+				craftComplete = false
+				continue
+			}
+			ingredient, complete := e.estimate(raw.(*gen.MappedMultilangItem), entry.Quantity*need, path)
+			craftComplete = craftComplete && complete
+			craftCost += ingredient.TotalCost
+			ingredients = append(ingredients, ingredient)
+		}
+		delete(path, item.AnkamaId)
+
+		if !canBuy || (craftComplete && craftCost < price*float64(need)) {
+			node.Action = CraftActionCraft
+			node.TotalCost = craftCost
+			node.Ingredients = ingredients
+			return node, craftComplete
+		}
+
+		// buying is cheaper, so the ingredients stay in the inventory
+		e.owned = ownedBefore
+		e.missing = missingBefore
+	}
+
+	if canBuy {
+		node.Action = CraftActionBuy
+		node.TotalCost = price * float64(need)
+		return node, true
+	}
+
+	node.Action = CraftActionUnavailable
+	e.missing[item.AnkamaId] = true
+	return node, false
+}
+
+func collectShoppingList(node *APICraftCostNode, list map[int]*APIShoppingListEntry) {
+	switch node.Action {
+	case CraftActionBuy:
+		entry, ok := list[node.Item.Id]
+		if !ok {
+			entry = &APIShoppingListEntry{
+				Item:      node.Item,
+				UnitPrice: *node.UnitPrice,
+			}
+			list[node.Item.Id] = entry
+		}
+		bought := node.Quantity - node.OwnedUsed
+		entry.Quantity += bought
+		entry.TotalCost += node.TotalCost
+	case CraftActionCraft:
+		for i := range node.Ingredients {
+			collectShoppingList(&node.Ingredients[i], list)
+		}
+	}
+}
+
+func EstimateCraftCost(item *gen.MappedMultilangItem, request *APICraftCostRequest, lang string, txn *memdb.Txn) APICraftCost {
+	estimator := craftCostEstimator{
+		lang:    lang,
+		txn:     txn,
+		prices:  request.Prices,
+		owned:   copyOwned(request.Owned),
+		missing: make(map[int]bool),
+	}
+
+	plan, complete := estimator.estimate(item, request.Quantity, make(map[int]bool))
+	result := APICraftCost{
+		Quantity:      request.Quantity,
+		TotalCost:     plan.TotalCost,
+		Complete:      complete,
+		Plan:          plan,
+		ShoppingList:  []APIShoppingListEntry{},
+		MissingPrices: []int{},
+	}
+
+	shoppingList := make(map[int]*APIShoppingListEntry)
+	collectShoppingList(&result.Plan, shoppingList)
+	for _, entry := range shoppingList {
+		result.ShoppingList = append(result.ShoppingList, *entry)
+	}
+	sort.Slice(result.ShoppingList, func(i, j int) bool {
+		return result.ShoppingList[i].Item.Id < result.ShoppingList[j].Item.Id
+	})
+
+	for itemId := range estimator.missing {
+		result.MissingPrices = append(result.MissingPrices, itemId)
+	}
+	sort.Ints(result.MissingPrices)
+
+	return result
+}
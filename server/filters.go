@@ -0,0 +1,131 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dofusdude/api/gen"
+	"github.com/dofusdude/api/utils"
+	"github.com/hashicorp/go-memdb"
+)
+
+// EffectFilter keeps items that have an effect with the given element id within the optional bounds.
+type EffectFilter struct {
+	ElementId int
+	Min       *int
+	Max       *int
+}
+
+func optionalIntParam(values []string, idx int, name string) (*int, error) {
+	if idx >= len(values) || values[idx] == "" {
+		return nil, nil
+	}
+	value, err := strconv.Atoi(values[idx])
+	if err != nil {
+		return nil, fmt.Errorf("filter[%s]", name)
+	}
+	return &value, nil
+}
+
+// ParseEffectFilters reads the repeatable filter[effect], filter[effect_min] and filter[effect_max] parameters.
+// The n-th bound belongs to the n-th effect, so empty values can be used to skip a bound.
+func ParseEffectFilters(query url.Values) ([]EffectFilter, error) {
+	effects := query["filter[effect]"]
+	mins := query["filter[effect_min]"]
+	maxs := query["filter[effect_max]"]
+	if len(mins) > len(effects) || len(maxs) > len(effects) {
+		return nil, fmt.Errorf("filter[effect] missing for bound")
+	}
+
+	var filters []EffectFilter
+	for i, effect := range effects {
+		elementId, err := strconv.Atoi(effect)
+		if err != nil {
+			return nil, fmt.Errorf("filter[effect]")
+		}
+		filter := EffectFilter{ElementId: elementId}
+		if filter.Min, err = optionalIntParam(mins, i, "effect_min"); err != nil {
+			return nil, err
+		}
+		if filter.Max, err = optionalIntParam(maxs, i, "effect_max"); err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// MatchesEffectFilters checks all filters against the summed effects of an item. Ranged effects match
+// when any value of the range is within the bounds.
+func MatchesEffectFilters(effects []gen.MappedMultilangEffect, filters []EffectFilter) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	sums := make(map[int]*APISummedEffect)
+	SumEffects(effects, "en", sums)
+	for _, filter := range filters {
+		sum, ok := sums[filter.ElementId]
+		if !ok {
+			return false
+		}
+		if filter.Min != nil && sum.MaxInt < *filter.Min {
+			return false
+		}
+		if filter.Max != nil && sum.MinInt > *filter.Max {
+			return false
+		}
+	}
+	return true
+}
+
+var itemFilterFields = map[string]utils.FilterField{
+	"level":          {MeiliName: "level"},
+	"pods":           {MeiliName: "pods"},
+	"type_id":        {MeiliName: "type_id"},
+	"super_type_id":  {MeiliName: "super_type_id"},
+	"has_parent_set": {IsBool: true, MeiliName: "has_parent_set"},
+	"has_recipe":     {IsBool: true, MeiliName: "has_recipe"},
+}
+
+var setFilterFields = map[string]utils.FilterField{
+	"level":      {MeiliName: "highest_equipment_level"},
+	"item_count": {},
+}
+
+var mountFilterFields = map[string]utils.FilterField{
+	"family_id": {MeiliName: "family_id"},
+}
+
+// joinMeiliFilters combines filter strings with AND and skips empty ones.
+func joinMeiliFilters(filters ...string) string {
+	var parts []string
+	for _, filter := range filters {
+		if filter != "" {
+			parts = append(parts, filter)
+		}
+	}
+	return strings.Join(parts, " AND ")
+}
+
+func itemFilterValue(item *gen.MappedMultilangItem, field string, txn *memdb.Txn) int {
+	switch field {
+	case "level":
+		return item.Level
+	case "pods":
+		return item.Pods
+	case "type_id":
+		return item.Type.ItemTypeId
+	case "super_type_id":
+		return item.Type.SuperTypeId
+	case "has_parent_set":
+		return gen.BoolToInt(item.HasParentSet)
+	case "has_recipe":
+		_, exists := GetRecipeIfExists(item.AnkamaId, txn)
+		return gen.BoolToInt(exists)
+	}
+	return 0
+}
@@ -7,18 +7,24 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dofusdude/api/gen"
 	"github.com/dofusdude/api/utils"
 	"github.com/hashicorp/go-memdb"
-	"github.com/meilisearch/meilisearch-go"
 )
 
 var (
 	mountAllowedExpandFields     = []string{"effects"}
 	setAllowedExpandFields       = utils.Concat(mountAllowedExpandFields, []string{"equipment_ids"})
-	itemAllowedExpandFields      = utils.Concat(mountAllowedExpandFields, []string{"recipe", "description", "conditions"})
+	itemAllowedExpandFields      = utils.Concat(mountAllowedExpandFields, []string{"recipe", "description", "conditions", "dropped_by", "used_in"})
 	equipmentAllowedExpandFields = utils.Concat(itemAllowedExpandFields, []string{"range", "parent_set", "is_weapon", "pods", "critical_hit_probability", "critical_hit_bonus", "is_two_handed", "max_cast_per_turn", "ap_cost"})
+	spellAllowedExpandFields     = []string{"description", "spell_level_ids"}
+	classAllowedExpandFields     = []string{"description", "spells"}
+	monsterAllowedExpandFields   = []string{"grades", "drops"}
+	idolAllowedExpandFields      = []string{"spell", "synergies"}
+	companionAllowedExpandFields = []string{"spells", "characteristics"}
+	npcAllowedExpandFields       = []string{"dialog"}
 )
 
 func GetRecipeIfExists(itemId int, txn *memdb.Txn) (gen.MappedMultilangRecipe, bool) {
@@ -78,6 +84,26 @@ func MinMaxLevelMeiliFilterFromParams(filterMinLevel string, filterMaxLevel stri
 	return filterString, nil
 }
 
+const almanaxDateLayout = "2006-01-02"
+const almanaxMaxRangeDays = 366
+
+func parseAlmanaxDate(dateStr string, fallback time.Time) (time.Time, error) {
+	if dateStr == "" {
+		return fallback, nil
+	}
+	return time.Parse(almanaxDateLayout, dateStr)
+}
+
+// AlmanaxDayForDate looks up the calendar entry of the month and day of the date, so every year
+// uses the same offering for the same date.
+func AlmanaxDayForDate(date time.Time, txn *memdb.Txn) *gen.MappedMultilangAlmanax {
+	raw, err := txn.First(fmt.Sprintf("%s-almanax", utils.CurrentRedBlueVersionStr(Version.MemDb)), "date", int(date.Month()), date.Day())
+	if err != nil || raw == nil {
+		return nil
+	}
+	return raw.(*gen.MappedMultilangAlmanax)
+}
+
 // listings
 
 // all
@@ -98,6 +124,21 @@ func ListAllSets(w http.ResponseWriter, r *http.Request) {
 	ListSets(w, r)
 }
 
+func ListAllSpells(w http.ResponseWriter, r *http.Request) {
+	createAllQueryParams("spell", spellAllowedExpandFields, r)
+	ListSpells(w, r)
+}
+
+func ListAllMonsters(w http.ResponseWriter, r *http.Request) {
+	createAllQueryParams("monster", monsterAllowedExpandFields, r)
+	ListMonsters(w, r)
+}
+
+func ListAllCompanions(w http.ResponseWriter, r *http.Request) {
+	createAllQueryParams("companion", companionAllowedExpandFields, r)
+	ListCompanions(w, r)
+}
+
 func ListAllConsumables(w http.ResponseWriter, r *http.Request) {
 	createAllQueryParams("item", itemAllowedExpandFields, r)
 	ListConsumables(w, r)
@@ -130,6 +171,16 @@ func ListMounts(w http.ResponseWriter, r *http.Request) {
 	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
 
 	filterFamilyName := r.URL.Query().Get("filter[family_name]")
+	sortKeys, err := utils.ParseSort(r.URL.Query().Get("sort"), "", mountSortFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fieldFilters, err := utils.ParseFieldFilters(r.URL.Query(), mountFilterFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[mount]"))
 	var expansions *utils.Set
 	expansions = parseFields(expansionsParam)
@@ -150,7 +201,7 @@ func ListMounts(w http.ResponseWriter, r *http.Request) {
 	requestsTotal.Inc()
 	requestsMountsList.Inc()
 
-	var mounts []APIListMount
+	var matches []*gen.MappedMultilangMount
 	for obj := it.Next(); obj != nil; obj = it.Next() {
 		p := obj.(*gen.MappedMultilangMount)
 		if filterFamilyName != "" {
@@ -158,6 +209,18 @@ func ListMounts(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 		}
+		if !utils.MatchesFieldFilters(fieldFilters, func(field string) int {
+			return p.FamilyId // family_id is the only mount field
+		}) {
+			continue
+		}
+		matches = append(matches, p)
+	}
+
+	SortMounts(matches, sortKeys, lang)
+
+	var mounts []APIListMount
+	for _, p := range matches {
 		mount := RenderMountListEntry(p, lang)
 
 		if expansions.Has("effects") {
@@ -208,7 +271,16 @@ func ListSets(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	sortLevel := strings.ToLower(r.URL.Query().Get("sort[level]"))
+	sortKeys, err := utils.ParseSort(r.URL.Query().Get("sort"), r.URL.Query().Get("sort[level]"), setSortFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fieldFilters, err := utils.ParseFieldFilters(r.URL.Query(), setFilterFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	filterMinLevel := strings.ToLower(r.URL.Query().Get("filter[min_highest_equipment_level]"))
 	filterMaxLevel := strings.ToLower(r.URL.Query().Get("filter[max_highest_equipment_level]"))
 	filterMinLevelInt, filterMaxLevelInt, err := MinMaxLevelInt(filterMinLevel, filterMaxLevel, "highest_equipment_level")
@@ -229,7 +301,7 @@ func ListSets(w http.ResponseWriter, r *http.Request) {
 	requestsTotal.Inc()
 	requestsSetsList.Inc()
 
-	var sets []APIListSet
+	var matches []*gen.MappedMultilangSet
 	for obj := it.Next(); obj != nil; obj = it.Next() {
 		p := obj.(*gen.MappedMultilangSet)
 
@@ -245,6 +317,22 @@ func ListSets(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		if !utils.MatchesFieldFilters(fieldFilters, func(field string) int {
+			if field == "item_count" {
+				return len(p.ItemIds)
+			}
+			return p.Level
+		}) {
+			continue
+		}
+
+		matches = append(matches, p)
+	}
+
+	SortSets(matches, sortKeys, lang)
+
+	var sets []APIListSet
+	for _, p := range matches {
 		set := RenderSetListEntry(p, lang)
 
 		if expansions.Has("effects") {
@@ -266,19 +354,6 @@ func ListSets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if sortLevel != "" {
-		if sortLevel == "asc" {
-			sort.Slice(sets, func(i, j int) bool {
-				return sets[i].Level < sets[j].Level
-			})
-		}
-		if sortLevel == "desc" {
-			sort.Slice(sets, func(i, j int) bool {
-				return sets[i].Level > sets[j].Level
-			})
-		}
-	}
-
 	if pagination.ValidatePagination(total) != 0 {
 		w.WriteHeader(http.StatusBadRequest)
 		return
@@ -301,53 +376,93 @@ func ListSets(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func parseFields(expansionsParam string) *utils.Set {
-	expansions := utils.NewSet()
-	expansionContainsDiv := strings.Contains(expansionsParam, ",")
-	if len(expansionsParam) != 0 && expansionContainsDiv {
-		expansionsArr := strings.Split(expansionsParam, ",")
-		for _, expansion := range expansionsArr {
-			expansions.Add(expansion)
+func ListSpells(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
+
+	filterTypeName := strings.ToLower(r.URL.Query().Get("filter[type_name]"))
+	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[spell]"))
+	var expansions *utils.Set
+	expansions = parseFields(expansionsParam)
+	if !validateFields(expansions, spellAllowedExpandFields) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "spells"), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsSpellsList.Inc()
+
+	var spells []APIListSpell
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		p := obj.(*gen.MappedMultilangSpell)
+		if filterTypeName != "" {
+			if strings.ToLower(p.Type.LongName[lang]) != filterTypeName {
+				continue
+			}
+		}
+		spell := RenderSpellListEntry(p, lang)
+
+		if expansions.Has("description") {
+			description := p.Description[lang]
+			spell.Description = &description
+		}
+
+		if expansions.Has("spell_level_ids") {
+			spell.SpellLevels = p.SpellLevels
 		}
+
+		spells = append(spells, spell)
 	}
-	if len(expansionsParam) != 0 && !expansionContainsDiv {
-		expansions.Add(expansionsParam)
+
+	total := len(spells)
+	if total == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
 	}
 
-	return expansions
-}
+	if pagination.ValidatePagination(total) != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
-func validateFields(expansions *utils.Set, list []string) bool {
-	allowedFields := utils.NewSet()
-	for _, expansion := range list {
-		allowedFields.Add(expansion)
+	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
+	links, _ := pagination.BuildLinks(*r.URL, total)
+	paginatedSpells := spells[startIdx:endIdx]
+
+	response := APIPageSpell{
+		Items: paginatedSpells,
+		Links: links,
 	}
 
-	return expansions.Difference(allowedFields).Size() == 0
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 }
 
-func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
+func ListMonsters(w http.ResponseWriter, r *http.Request) {
 	lang := r.Context().Value("lang").(string)
 	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
 
-	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[item]"))
+	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[monster]"))
 	var expansions *utils.Set
-	if itemType == "equipment" {
-		expansions = parseFields(expansionsParam)
-		if !validateFields(expansions, equipmentAllowedExpandFields) {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-	} else {
-		expansions = parseFields(expansionsParam)
-		if !validateFields(expansions, itemAllowedExpandFields) {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
+	expansions = parseFields(expansionsParam)
+	if !validateFields(expansions, monsterAllowedExpandFields) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
 	}
-
-	sortLevel := strings.ToLower(r.URL.Query().Get("sort[level]"))
-	filterTypeName := strings.ToLower(r.URL.Query().Get("filter[type_name]"))
+	filterRaceName := strings.ToLower(r.URL.Query().Get("filter[race_name]"))
 	filterMinLevel := strings.ToLower(r.URL.Query().Get("filter[min_level]"))
 	filterMaxLevel := strings.ToLower(r.URL.Query().Get("filter[max_level]"))
 	filterMinLevelInt, filterMaxLevelInt, err := MinMaxLevelInt(filterMinLevel, filterMaxLevel, "level")
@@ -359,140 +474,55 @@ func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
 	txn := Db.Txn(false)
 	defer txn.Abort()
 
-	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), itemType), "id")
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "monsters"), "id")
 	if err != nil || it == nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
 
-	requestsItemsList.Inc()
 	requestsTotal.Inc()
+	requestsMonstersList.Inc()
 
-	var items []APIListItem
+	var monsters []APIListMonster
 	for obj := it.Next(); obj != nil; obj = it.Next() {
-		p := obj.(*gen.MappedMultilangItem)
-
-		if filterTypeName != "" {
-			if strings.ToLower(p.Type.Name[lang]) != filterTypeName {
+		p := obj.(*gen.MappedMultilangMonster)
+		if filterRaceName != "" {
+			if strings.ToLower(p.Race.Name[lang]) != filterRaceName {
 				continue
 			}
 		}
 
+		monster := RenderMonsterListEntry(p, lang)
+
 		if filterMinLevel != "" {
-			if p.Level < filterMinLevelInt {
+			if monster.Level.Max < filterMinLevelInt {
 				continue
 			}
 		}
 
 		if filterMaxLevel != "" {
-			if p.Level > filterMaxLevelInt {
+			if monster.Level.Min > filterMaxLevelInt {
 				continue
 			}
 		}
 
-		item := RenderItemListEntry(p, lang)
-		// items extra fields
-		if expansions.Has("recipe") {
-			recipe, exists := GetRecipeIfExists(item.Id, txn)
-			if exists {
-				item.Recipe = RenderRecipe(recipe, Db)
-			} else {
-				item.Recipe = nil
-			}
-		}
-
-		if expansions.Has("description") {
-			description := p.Description[lang]
-			item.Description = &description
-		}
-
-		if expansions.Has("conditions") {
-			if p.Conditions != nil {
-				item.Conditions = RenderConditions(&p.Conditions, lang)
-			}
-		}
-
-		if expansions.Has("effects") {
-			if p.Effects != nil {
-				renderedEffects := RenderEffects(&p.Effects, lang)
-				if len(renderedEffects) != 0 {
-					item.Effects = renderedEffects
-				}
-			}
-		}
-
-		// equipment extra fields
-		mIsWeapon := p.Type.SuperTypeId == 2 // is weapon
-		if expansions.Has("is_weapon") {
-			item.IsWeapon = &mIsWeapon
-		}
-
-		if expansions.Has("pods") {
-			item.Pods = &p.Pods
-		}
-
-		if expansions.Has("parent_set") {
-			if p.HasParentSet {
-				item.ParentSet = &APISetReverseLink{
-					Id:   p.ParentSet.Id,
-					Name: p.ParentSet.Name[lang],
-				}
-			}
+		if expansions.Has("grades") {
+			monster.Grades = RenderMonsterGrades(p)
 		}
 
-		// weapon extra fields
-		if mIsWeapon {
-			if expansions.Has("critical_hit_probability") {
-				item.CriticalHitProbability = &p.CriticalHitProbability
-			}
-
-			if expansions.Has("critical_hit_bonus") {
-				item.CriticalHitBonus = &p.CriticalHitBonus
-			}
-
-			if expansions.Has("is_two_handed") {
-				item.TwoHanded = &p.TwoHanded
-			}
-
-			if expansions.Has("max_cast_per_turn") {
-				item.MaxCastPerTurn = &p.MaxCastPerTurn
-			}
-
-			if expansions.Has("ap_cost") {
-				item.ApCost = &p.ApCost
-			}
-
-			if expansions.Has("range") {
-				item.Range = &APIRange{
-					Min: p.MinRange,
-					Max: p.Range,
-				}
-			}
+		if expansions.Has("drops") {
+			monster.Drops = RenderMonsterDrops(p, lang, Db)
 		}
 
-		items = append(items, item)
+		monsters = append(monsters, monster)
 	}
 
-	if len(items) == 0 {
+	total := len(monsters)
+	if total == 0 {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
 
-	if sortLevel != "" {
-		if sortLevel == "asc" {
-			sort.Slice(items, func(i, j int) bool {
-				return items[i].Level < items[j].Level
-			})
-		}
-		if sortLevel == "desc" {
-			sort.Slice(items, func(i, j int) bool {
-				return items[i].Level > items[j].Level
-			})
-		}
-	}
-
-	total := len(items)
-
 	if pagination.ValidatePagination(total) != 0 {
 		w.WriteHeader(http.StatusBadRequest)
 		return
@@ -500,10 +530,10 @@ func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
 
 	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
 	links, _ := pagination.BuildLinks(*r.URL, total)
-	paginatedItems := items[startIdx:endIdx]
+	paginatedMonsters := monsters[startIdx:endIdx]
 
-	response := APIPageItem{
-		Items: paginatedItems,
+	response := APIPageMonster{
+		Items: paginatedMonsters,
 		Links: links,
 	}
 
@@ -515,47 +545,1319 @@ func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func ListConsumables(w http.ResponseWriter, r *http.Request) {
-	ListItems("consumables", w, r)
-}
+func ListIdols(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
 
-func ListEquipment(w http.ResponseWriter, r *http.Request) {
-	ListItems("equipment", w, r)
-}
+	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[idol]"))
+	var expansions *utils.Set
+	expansions = parseFields(expansionsParam)
+	if !validateFields(expansions, idolAllowedExpandFields) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	filterMinScore := r.URL.Query().Get("filter[min_score]")
+	filterMaxScore := r.URL.Query().Get("filter[max_score]")
+	filterMinScoreInt, filterMaxScoreInt, err := MinMaxLevelInt(filterMinScore, filterMaxScore, "score")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	filterGroupOnly := strings.ToLower(r.URL.Query().Get("filter[group_only]"))
+	var filterGroupOnlyBool bool
+	if filterGroupOnly != "" {
+		if filterGroupOnlyBool, err = strconv.ParseBool(filterGroupOnly); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
 
-func ListResources(w http.ResponseWriter, r *http.Request) {
-	ListItems("resources", w, r)
-}
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "idols"), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsIdolsList.Inc()
+
+	var idols []APIListIdol
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		p := obj.(*gen.MappedMultilangIdol)
+		if filterMinScore != "" && p.Score < filterMinScoreInt {
+			continue
+		}
+
+		if filterMaxScore != "" && p.Score > filterMaxScoreInt {
+			continue
+		}
+
+		if filterGroupOnly != "" && p.GroupOnly != filterGroupOnlyBool {
+			continue
+		}
+
+		idol := RenderIdolListEntry(p, lang)
+
+		if expansions.Has("spell") {
+			spell := RenderIdolSpell(p, lang)
+			idol.Spell = &spell
+		}
+
+		if expansions.Has("synergies") {
+			idol.Synergies = RenderIdolSynergies(p, lang, Db)
+		}
+
+		idols = append(idols, idol)
+	}
+
+	total := len(idols)
+	if total == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if pagination.ValidatePagination(total) != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
+	links, _ := pagination.BuildLinks(*r.URL, total)
+	paginatedIdols := idols[startIdx:endIdx]
+
+	response := APIPageIdol{
+		Items: paginatedIdols,
+		Links: links,
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func ListCompanions(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
+
+	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[companion]"))
+	var expansions *utils.Set
+	expansions = parseFields(expansionsParam)
+	if !validateFields(expansions, companionAllowedExpandFields) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "companions"), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsCompanionsList.Inc()
+
+	var companions []APIListCompanion
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		p := obj.(*gen.MappedMultilangCompanion)
+		companion := RenderCompanionListEntry(p, lang)
+
+		if expansions.Has("spells") {
+			companion.Spells = RenderCompanionSpells(p, lang, Db)
+		}
+
+		if expansions.Has("characteristics") {
+			companion.Characteristics = RenderCompanionCharacteristics(p, lang)
+		}
+
+		companions = append(companions, companion)
+	}
+
+	total := len(companions)
+	if total == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if pagination.ValidatePagination(total) != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
+	links, _ := pagination.BuildLinks(*r.URL, total)
+	paginatedCompanions := companions[startIdx:endIdx]
+
+	response := APIPageCompanion{
+		Items: paginatedCompanions,
+		Links: links,
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func ListAreas(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+
+	filterSuperAreaId := r.URL.Query().Get("filter[super_area_id]")
+	var filterSuperAreaIdInt int
+	if filterSuperAreaId != "" {
+		var err error
+		if filterSuperAreaIdInt, err = strconv.Atoi(filterSuperAreaId); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "areas"), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsAreasList.Inc()
+
+	var areas []APIArea
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		p := obj.(*gen.MappedMultilangArea)
+		if filterSuperAreaId != "" && p.SuperAreaId != filterSuperAreaIdInt {
+			continue
+		}
+		areas = append(areas, RenderArea(p, lang))
+	}
+
+	if len(areas) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(areas)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func ListSuperAreas(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "superareas"), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsSuperAreasList.Inc()
+
+	var superAreas []APIListSuperArea
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		superAreas = append(superAreas, RenderSuperAreaListEntry(obj.(*gen.MappedMultilangSuperArea), lang))
+	}
+
+	if len(superAreas) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(superAreas)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func ListNpcs(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
+
+	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[npc]"))
+	var expansions *utils.Set
+	expansions = parseFields(expansionsParam)
+	if !validateFields(expansions, npcAllowedExpandFields) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "npcs"), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsNpcsList.Inc()
+
+	var npcs []APIListNpc
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		p := obj.(*gen.MappedMultilangNpc)
+		npc := RenderNpcListEntry(p, lang)
+
+		if expansions.Has("dialog") {
+			dialog := RenderNpcDialog(p, lang)
+			npc.Dialog = &dialog
+		}
+
+		npcs = append(npcs, npc)
+	}
+
+	total := len(npcs)
+	if total == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if pagination.ValidatePagination(total) != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
+	links, _ := pagination.BuildLinks(*r.URL, total)
+	paginatedNpcs := npcs[startIdx:endIdx]
+
+	response := APIPageNpc{
+		Items: paginatedNpcs,
+		Links: links,
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func ListJobs(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "jobs"), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsJobsList.Inc()
+
+	var jobs []APIListJob
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		jobs = append(jobs, RenderJobListEntry(obj.(*gen.MappedMultilangJob), lang))
+	}
+
+	if len(jobs) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(jobs)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func ListJobRecipes(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
+
+	filterMinLevel := r.URL.Query().Get("filter[min_level]")
+	filterMaxLevel := r.URL.Query().Get("filter[max_level]")
+	filterMinLevelInt, filterMaxLevelInt, err := MinMaxLevelInt(filterMinLevel, filterMaxLevel, "level")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	job, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "jobs"), "id", ankamaId)
+	if err != nil || job == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "recipes"), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsJobRecipesList.Inc()
+
+	var recipes []*gen.MappedMultilangRecipe
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		p := obj.(*gen.MappedMultilangRecipe)
+		if p.JobId != ankamaId {
+			continue
+		}
+
+		if filterMinLevel != "" && p.Level < filterMinLevelInt {
+			continue
+		}
+
+		if filterMaxLevel != "" && p.Level > filterMaxLevelInt {
+			continue
+		}
+
+		recipes = append(recipes, p)
+	}
+
+	sort.SliceStable(recipes, func(i, j int) bool {
+		if recipes[i].Level == recipes[j].Level {
+			return recipes[i].ResultId < recipes[j].ResultId
+		}
+		return recipes[i].Level < recipes[j].Level
+	})
+
+	total := len(recipes)
+	if total == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if pagination.ValidatePagination(total) != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
+	links, _ := pagination.BuildLinks(*r.URL, total)
+
+	var jobRecipes []APIJobRecipe
+	for _, recipe := range recipes[startIdx:endIdx] {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", recipe.ResultId)
+		if err != nil || raw == nil {
+			continue
+		}
+		jobRecipes = append(jobRecipes, RenderJobRecipe(recipe, raw.(*gen.MappedMultilangItem), lang))
+	}
+
+	response := APIPageJobRecipe{
+		Items: jobRecipes,
+		Links: links,
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func ListClasses(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+
+	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[class]"))
+	var expansions *utils.Set
+	expansions = parseFields(expansionsParam)
+	if !validateFields(expansions, classAllowedExpandFields) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "classes"), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsClassesList.Inc()
+
+	var classes []APIListClass
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		p := obj.(*gen.MappedMultilangBreed)
+		class := RenderClassListEntry(p, lang)
+
+		if expansions.Has("description") {
+			description := p.Description[lang]
+			class.Description = &description
+		}
+
+		if expansions.Has("spells") {
+			class.Spells = RenderClassSpells(p, lang, Db)
+		}
+
+		classes = append(classes, class)
+	}
+
+	if len(classes) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(classes)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func parseFields(expansionsParam string) *utils.Set {
+	expansions := utils.NewSet()
+	expansionContainsDiv := strings.Contains(expansionsParam, ",")
+	if len(expansionsParam) != 0 && expansionContainsDiv {
+		expansionsArr := strings.Split(expansionsParam, ",")
+		for _, expansion := range expansionsArr {
+			expansions.Add(expansion)
+		}
+	}
+	if len(expansionsParam) != 0 && !expansionContainsDiv {
+		expansions.Add(expansionsParam)
+	}
+
+	return expansions
+}
+
+func validateFields(expansions *utils.Set, list []string) bool {
+	allowedFields := utils.NewSet()
+	for _, expansion := range list {
+		allowedFields.Add(expansion)
+	}
+
+	return expansions.Difference(allowedFields).Size() == 0
+}
+
+func ListItems(itemType string, w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
+
+	expansionsParam := strings.ToLower(r.URL.Query().Get("fields[item]"))
+	var expansions *utils.Set
+	if itemType == "equipment" {
+		expansions = parseFields(expansionsParam)
+		if !validateFields(expansions, equipmentAllowedExpandFields) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	} else {
+		expansions = parseFields(expansionsParam)
+		if !validateFields(expansions, itemAllowedExpandFields) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	sortKeys, err := utils.ParseSort(r.URL.Query().Get("sort"), r.URL.Query().Get("sort[level]"), itemSortFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	filterTypeName := strings.ToLower(r.URL.Query().Get("filter[type_name]"))
+	filterMinLevel := strings.ToLower(r.URL.Query().Get("filter[min_level]"))
+	filterMaxLevel := strings.ToLower(r.URL.Query().Get("filter[max_level]"))
+	filterMinLevelInt, filterMaxLevelInt, err := MinMaxLevelInt(filterMinLevel, filterMaxLevel, "level")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	effectFilters, err := ParseEffectFilters(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fieldFilters, err := utils.ParseFieldFilters(r.URL.Query(), itemFilterFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), itemType), "id")
+	if err != nil || it == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsItemsList.Inc()
+	requestsTotal.Inc()
+
+	var matches []*gen.MappedMultilangItem
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		p := obj.(*gen.MappedMultilangItem)
+
+		if filterTypeName != "" {
+			if strings.ToLower(p.Type.Name[lang]) != filterTypeName {
+				continue
+			}
+		}
+
+		if filterMinLevel != "" {
+			if p.Level < filterMinLevelInt {
+				continue
+			}
+		}
+
+		if filterMaxLevel != "" {
+			if p.Level > filterMaxLevelInt {
+				continue
+			}
+		}
+
+		if !MatchesEffectFilters(p.Effects, effectFilters) {
+			continue
+		}
+
+		if !utils.MatchesFieldFilters(fieldFilters, func(field string) int {
+			return itemFilterValue(p, field, txn)
+		}) {
+			continue
+		}
+
+		matches = append(matches, p)
+	}
+
+	SortItems(matches, sortKeys, lang)
+
+	var items []APIListItem
+	for _, p := range matches {
+		item := RenderItemListEntry(p, lang)
+		// items extra fields
+		if expansions.Has("recipe") {
+			recipe, exists := GetRecipeIfExists(item.Id, txn)
+			if exists {
+				item.Recipe = RenderRecipe(recipe, Db)
+			} else {
+				item.Recipe = nil
+			}
+		}
+
+		if expansions.Has("description") {
+			description := p.Description[lang]
+			item.Description = &description
+		}
+
+		if expansions.Has("conditions") {
+			if p.Conditions != nil {
+				item.Conditions = RenderConditions(&p.Conditions, lang)
+			}
+			item.ConditionsTree = RenderConditionTree(p.ConditionsTree, lang)
+		}
+
+		if expansions.Has("effects") {
+			if p.Effects != nil {
+				renderedEffects := RenderEffects(&p.Effects, lang)
+				if len(renderedEffects) != 0 {
+					item.Effects = renderedEffects
+				}
+			}
+		}
+
+		if expansions.Has("dropped_by") {
+			item.DroppedBy = RenderDroppedBy(p, lang, Db)
+		}
+
+		if expansions.Has("used_in") {
+			item.UsedIn = RenderUsedIn(p, lang, Db)
+		}
+
+		// equipment extra fields
+		mIsWeapon := p.Type.SuperTypeId == 2 // is weapon
+		if expansions.Has("is_weapon") {
+			item.IsWeapon = &mIsWeapon
+		}
+
+		if expansions.Has("pods") {
+			item.Pods = &p.Pods
+		}
+
+		if expansions.Has("parent_set") {
+			if p.HasParentSet {
+				item.ParentSet = &APISetReverseLink{
+					Id:   p.ParentSet.Id,
+					Name: p.ParentSet.Name[lang],
+				}
+			}
+		}
+
+		// weapon extra fields
+		if mIsWeapon {
+			if expansions.Has("critical_hit_probability") {
+				item.CriticalHitProbability = &p.CriticalHitProbability
+			}
+
+			if expansions.Has("critical_hit_bonus") {
+				item.CriticalHitBonus = &p.CriticalHitBonus
+			}
+
+			if expansions.Has("is_two_handed") {
+				item.TwoHanded = &p.TwoHanded
+			}
+
+			if expansions.Has("max_cast_per_turn") {
+				item.MaxCastPerTurn = &p.MaxCastPerTurn
+			}
+
+			if expansions.Has("ap_cost") {
+				item.ApCost = &p.ApCost
+			}
+
+			if expansions.Has("range") {
+				item.Range = &APIRange{
+					Min: p.MinRange,
+					Max: p.Range,
+				}
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	total := len(items)
+
+	if pagination.ValidatePagination(total) != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
+	links, _ := pagination.BuildLinks(*r.URL, total)
+	paginatedItems := items[startIdx:endIdx]
+
+	response := APIPageItem{
+		Items: paginatedItems,
+		Links: links,
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func ListConsumables(w http.ResponseWriter, r *http.Request) {
+	ListItems("consumables", w, r)
+}
+
+func ListEquipment(w http.ResponseWriter, r *http.Request) {
+	ListItems("equipment", w, r)
+}
+
+func ListResources(w http.ResponseWriter, r *http.Request) {
+	ListItems("resources", w, r)
+}
 
 func ListQuestItems(w http.ResponseWriter, r *http.Request) {
 	ListItems("quest_items", w, r)
 }
 
-func ListCosmetics(w http.ResponseWriter, r *http.Request) {
-	ListItems("cosmetics", w, r)
-}
+func ListCosmetics(w http.ResponseWriter, r *http.Request) {
+	ListItems("cosmetics", w, r)
+}
+
+func ListItemUsedIn(itemType string, w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+	pagination := utils.PageninationWithState(r.Context().Value("pagination").(string))
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), itemType), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsItemsUsedIn.Inc()
+
+	usedIn := RenderUsedIn(raw.(*gen.MappedMultilangItem), lang, Db)
+	total := len(usedIn)
+	if total == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if pagination.ValidatePagination(total) != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	startIdx, endIdx := pagination.CalculateStartEndIndex(total)
+	links, _ := pagination.BuildLinks(*r.URL, total)
+
+	response := APIPageTypedItem{
+		Items: usedIn[startIdx:endIdx],
+		Links: links,
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func ListConsumablesUsedIn(w http.ResponseWriter, r *http.Request) {
+	ListItemUsedIn("consumables", w, r)
+}
+
+func ListEquipmentUsedIn(w http.ResponseWriter, r *http.Request) {
+	ListItemUsedIn("equipment", w, r)
+}
+
+func ListResourcesUsedIn(w http.ResponseWriter, r *http.Request) {
+	ListItemUsedIn("resources", w, r)
+}
+
+func ListQuestItemsUsedIn(w http.ResponseWriter, r *http.Request) {
+	ListItemUsedIn("quest_items", w, r)
+}
+
+func ListCosmeticsUsedIn(w http.ResponseWriter, r *http.Request) {
+	ListItemUsedIn("cosmetics", w, r)
+}
+
+const maxRecipeTreeQuantity = 100000
+
+func GetItemRecipeTree(itemType string, w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	quantity := 1
+	quantityStr := r.URL.Query().Get("quantity")
+	if quantityStr != "" {
+		var err error
+		if quantity, err = strconv.Atoi(quantityStr); err != nil || quantity <= 0 || quantity > maxRecipeTreeQuantity {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), itemType), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if _, exists := GetRecipeIfExists(ankamaId, txn); !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsItemsRecipeTree.Inc()
+
+	tree := RenderRecipeTree(raw.(*gen.MappedMultilangItem), quantity, lang, txn)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(tree)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+const maxRequestBodyBytes = 1 << 20
+
+func EstimateCraftCostHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+
+	var request APICraftCostRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.Quantity == 0 {
+		request.Quantity = 1
+	}
+	if request.Quantity < 0 || request.Quantity > maxRecipeTreeQuantity {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	for _, price := range request.Prices {
+		if price < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	for _, owned := range request.Owned {
+		if owned < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", request.AnkamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsCraftCost.Inc()
+
+	estimation := EstimateCraftCost(raw.(*gen.MappedMultilangItem), &request, lang, txn)
+	utils.SetJsonHeader(&w)
+	err = json.NewEncoder(w).Encode(estimation)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func CalculateSetBonusesHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+
+	var request APISetBonusRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.ItemIds) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	requestsTotal.Inc()
+	requestsSetBonuses.Inc()
+
+	bonuses := CalculateSetBonuses(request.ItemIds, lang, txn)
+	utils.SetJsonHeader(&w)
+	err := json.NewEncoder(w).Encode(bonuses)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func EvaluateBuildHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+
+	var request APIBuildRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || !ValidateBuildRequest(&request) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "classes"), "id", request.ClassId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsBuildEvaluate.Inc()
+
+	build := EvaluateBuild(raw.(*gen.MappedMultilangBreed), &request, lang, txn)
+	utils.SetJsonHeader(&w)
+	err = json.NewEncoder(w).Encode(build)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func EvaluateItemConditionsHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+
+	var request APIConditionEvaluationRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", request.AnkamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsConditionEvaluate.Inc()
+
+	evaluation := RenderConditionEvaluation(raw.(*gen.MappedMultilangItem), &request.Profile, lang)
+	utils.SetJsonHeader(&w)
+	err = json.NewEncoder(w).Encode(evaluation)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetConsumableRecipeTree(w http.ResponseWriter, r *http.Request) {
+	GetItemRecipeTree("consumables", w, r)
+}
+
+func GetEquipmentRecipeTree(w http.ResponseWriter, r *http.Request) {
+	GetItemRecipeTree("equipment", w, r)
+}
+
+func GetResourceRecipeTree(w http.ResponseWriter, r *http.Request) {
+	GetItemRecipeTree("resources", w, r)
+}
+
+func GetQuestItemRecipeTree(w http.ResponseWriter, r *http.Request) {
+	GetItemRecipeTree("quest_items", w, r)
+}
+
+func GetCosmeticRecipeTree(w http.ResponseWriter, r *http.Request) {
+	GetItemRecipeTree("cosmetics", w, r)
+}
+
+func getLimitInBoundary(limitStr string) (int64, error) {
+	if limitStr == "" {
+		limitStr = "8"
+	}
+	var limit int
+	var err error
+	if limit, err = strconv.Atoi(limitStr); err != nil {
+		return 0, fmt.Errorf("invalid limit value")
+	}
+	if limit > 100 {
+		return 0, fmt.Errorf("limit value is too high")
+	}
+
+	return int64(limit), nil
+}
+
+// search
+
+func SearchMounts(w http.ResponseWriter, r *http.Request) {
+	var err error
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var searchLimit int64
+	if searchLimit, err = getLimitInBoundary(r.URL.Query().Get("limit")); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	page, err := ParseSearchPage(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	familyName := strings.ToLower(r.URL.Query().Get("filter[family_name]"))
+
+	lang := r.Context().Value("lang").(string)
+
+	index := utils.Search.Index(fmt.Sprintf("%s-mounts-%s", utils.CurrentRedBlueVersionStr(Version.Search), lang))
+	var request *utils.SearchRequest
+	filterString := ""
+	if familyName != "" {
+		filterString = fmt.Sprintf("family_name=%s", familyName)
+	}
+	fieldFilters, err := utils.ParseFieldFilters(r.URL.Query(), mountFilterFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fieldFilterString, err := utils.MeiliFilterFromFieldFilters(fieldFilters, mountFilterFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	effectFilterString, err := SearchEffectFilter(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	filterString = joinMeiliFilters(filterString, fieldFilterString, effectFilterString)
+
+	if filterString == "" {
+		request = &utils.SearchRequest{
+			Limit: searchLimit,
+		}
+	} else {
+		request = &utils.SearchRequest{
+			Limit:  searchLimit,
+			Filter: filterString,
+		}
+	}
+
+	ApplySearchPage(request, page)
+
+	searchResp, err := index.Search(query, request)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsMountsSearch.Inc()
+
+	if searchResp.EstimatedTotalHits == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if page != nil && len(searchResp.Hits) == 0 { // page out of range
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
-func getLimitInBoundary(limitStr string) (int64, error) {
-	if limitStr == "" {
-		limitStr = "8"
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	var mounts []APIListMount
+	for _, hit := range searchResp.Hits {
+		itemId := int(hit["id"].(float64))
+
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "mounts"), "id", itemId)
+		if err != nil || raw == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		item := raw.(*gen.MappedMultilangMount)
+		mounts = append(mounts, RenderMountListEntry(item, lang))
 	}
-	var limit int
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(RenderSearchResults(mounts, searchResp, page, nil, *r.URL))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func SearchCompanions(w http.ResponseWriter, r *http.Request) {
 	var err error
-	if limit, err = strconv.Atoi(limitStr); err != nil {
-		return 0, fmt.Errorf("invalid limit value")
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
 	}
-	if limit > 100 {
-		return 0, fmt.Errorf("limit value is too high")
+
+	var searchLimit int64
+	if searchLimit, err = getLimitInBoundary(r.URL.Query().Get("limit")); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
 	}
 
-	return int64(limit), nil
+	page, err := ParseSearchPage(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	lang := r.Context().Value("lang").(string)
+
+	index := utils.Search.Index(fmt.Sprintf("%s-companions-%s", utils.CurrentRedBlueVersionStr(Version.Search), lang))
+	request := &utils.SearchRequest{
+		Limit: searchLimit,
+	}
+
+	ApplySearchPage(request, page)
+
+	searchResp, err := index.Search(query, request)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsCompanionsSearch.Inc()
+
+	if searchResp.EstimatedTotalHits == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if page != nil && len(searchResp.Hits) == 0 { // page out of range
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	var companions []APIListCompanion
+	for _, hit := range searchResp.Hits {
+		itemId := int(hit["id"].(float64))
+
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "companions"), "id", itemId)
+		if err != nil || raw == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		item := raw.(*gen.MappedMultilangCompanion)
+		companions = append(companions, RenderCompanionListEntry(item, lang))
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(RenderSearchResults(companions, searchResp, page, nil, *r.URL))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 }
 
-// search
+func SearchSets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
-func SearchMounts(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	filterMinLevel := strings.ToLower(r.URL.Query().Get("filter[min_highest_equipment_level]"))
+	filterMaxLevel := strings.ToLower(r.URL.Query().Get("filter[max_highest_equipment_level]"))
+	filterString, err := MinMaxLevelMeiliFilterFromParams(filterMinLevel, filterMaxLevel, "highest_equipment_level")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fieldFilters, err := utils.ParseFieldFilters(r.URL.Query(), setFilterFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fieldFilterString, err := utils.MeiliFilterFromFieldFilters(fieldFilters, setFilterFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	effectFilterString, err := SearchEffectFilter(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	filterString = joinMeiliFilters(filterString, fieldFilterString, effectFilterString)
+
+	var searchLimit int64
+	if searchLimit, err = getLimitInBoundary(r.URL.Query().Get("limit")); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	page, err := ParseSearchPage(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	index := utils.Search.Index(fmt.Sprintf("%s-sets-%s", utils.CurrentRedBlueVersionStr(Version.Search), lang))
+	var request *utils.SearchRequest
+
+	if filterString == "" {
+		request = &utils.SearchRequest{
+			Limit: searchLimit,
+		}
+	} else {
+		request = &utils.SearchRequest{
+			Limit:  searchLimit,
+			Filter: filterString,
+		}
+	}
+
+	ApplySearchPage(request, page)
+
+	searchResp, err := index.Search(query, request)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsSetsSearch.Inc()
+
+	if searchResp.EstimatedTotalHits == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if page != nil && len(searchResp.Hits) == 0 { // page out of range
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	var sets []APIListSet
+	for _, hit := range searchResp.Hits {
+		itemId := int(hit["id"].(float64))
+
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "sets"), "id", itemId)
+		if err != nil || raw == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		item := raw.(*gen.MappedMultilangSet)
+		sets = append(sets, RenderSetListEntry(item, lang))
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(RenderSearchResults(sets, searchResp, page, nil, *r.URL))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func SearchSpells(w http.ResponseWriter, r *http.Request) {
 	var err error
-	client := utils.CreateMeiliClient()
 	query := r.URL.Query().Get("query")
 	if query == "" {
 		w.WriteHeader(http.StatusBadRequest)
@@ -568,28 +1870,36 @@ func SearchMounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	familyName := strings.ToLower(r.URL.Query().Get("filter[family_name]"))
+	page, err := ParseSearchPage(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	typeName := strings.ToLower(r.URL.Query().Get("filter[type_name]"))
 
 	lang := r.Context().Value("lang").(string)
 
-	index := client.Index(fmt.Sprintf("%s-mounts-%s", utils.CurrentRedBlueVersionStr(Version.Search), lang))
-	var request *meilisearch.SearchRequest
+	index := utils.Search.Index(fmt.Sprintf("%s-spells-%s", utils.CurrentRedBlueVersionStr(Version.Search), lang))
+	var request *utils.SearchRequest
 	filterString := ""
-	if familyName != "" {
-		filterString = fmt.Sprintf("family_name=%s", familyName)
+	if typeName != "" {
+		filterString = fmt.Sprintf("type_name='%s'", typeName)
 	}
 
 	if filterString == "" {
-		request = &meilisearch.SearchRequest{
+		request = &utils.SearchRequest{
 			Limit: searchLimit,
 		}
 	} else {
-		request = &meilisearch.SearchRequest{
+		request = &utils.SearchRequest{
 			Limit:  searchLimit,
 			Filter: filterString,
 		}
 	}
 
+	ApplySearchPage(request, page)
+
 	searchResp, err := index.Search(query, request)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -597,41 +1907,43 @@ func SearchMounts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	requestsTotal.Inc()
-	requestsMountsSearch.Inc()
+	requestsSpellsSearch.Inc()
 
 	if searchResp.EstimatedTotalHits == 0 {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
+	if page != nil && len(searchResp.Hits) == 0 { // page out of range
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
 	txn := Db.Txn(false)
 	defer txn.Abort()
 
-	var mounts []APIListMount
+	var spells []APIListSpell
 	for _, hit := range searchResp.Hits {
-		indexed := hit.(map[string]interface{})
-		itemId := int(indexed["id"].(float64))
+		spellId := int(hit["id"].(float64))
 
-		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "mounts"), "id", itemId)
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "spells"), "id", spellId)
 		if err != nil || raw == nil {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
 
-		item := raw.(*gen.MappedMultilangMount)
-		mounts = append(mounts, RenderMountListEntry(item, lang))
+		spell := raw.(*gen.MappedMultilangSpell)
+		spells = append(spells, RenderSpellListEntry(spell, lang))
 	}
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(mounts)
+	err = json.NewEncoder(w).Encode(RenderSearchResults(spells, searchResp, page, nil, *r.URL))
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func SearchSets(w http.ResponseWriter, r *http.Request) {
-	client := utils.CreateMeiliClient()
+func SearchMonsters(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("query")
 	if query == "" {
 		w.WriteHeader(http.StatusBadRequest)
@@ -639,37 +1951,50 @@ func SearchSets(w http.ResponseWriter, r *http.Request) {
 	}
 
 	lang := r.Context().Value("lang").(string)
-	filterMinLevel := strings.ToLower(r.URL.Query().Get("filter[min_highest_equipment_level]"))
-	filterMaxLevel := strings.ToLower(r.URL.Query().Get("filter[max_highest_equipment_level]"))
-	filterString, err := MinMaxLevelMeiliFilterFromParams(filterMinLevel, filterMaxLevel, "highest_equipment_level")
+	filterRaceName := strings.ToLower(r.URL.Query().Get("filter[race_name]"))
+	filterMinLevel := strings.ToLower(r.URL.Query().Get("filter[min_level]"))
+	filterMaxLevel := strings.ToLower(r.URL.Query().Get("filter[max_level]"))
+	filterString, err := MinMaxLevelMeiliFilterFromParams(filterMinLevel, filterMaxLevel, "level")
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
+	if filterRaceName != "" {
+		if filterString == "" {
+			filterString = fmt.Sprintf("race_name='%s'", filterRaceName)
+		} else {
+			filterString = fmt.Sprintf("%s AND race_name='%s'", filterString, filterRaceName)
+		}
+	}
+
 	var searchLimit int64
 	if searchLimit, err = getLimitInBoundary(r.URL.Query().Get("limit")); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	index := client.Index(fmt.Sprintf("%s-sets-%s", utils.CurrentRedBlueVersionStr(Version.Search), lang))
-	var request *meilisearch.SearchRequest
+	page, err := ParseSearchPage(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	index := utils.Search.Index(fmt.Sprintf("%s-monsters-%s", utils.CurrentRedBlueVersionStr(Version.Search), lang))
+	var request *utils.SearchRequest
 
 	if filterString == "" {
-		request = &meilisearch.SearchRequest{
+		request = &utils.SearchRequest{
 			Limit: searchLimit,
 		}
 	} else {
-		request = &meilisearch.SearchRequest{
+		request = &utils.SearchRequest{
 			Limit:  searchLimit,
 			Filter: filterString,
 		}
 	}
 
-	request = &meilisearch.SearchRequest{
-		Limit: searchLimit,
-	}
+	ApplySearchPage(request, page)
 
 	searchResp, err := index.Search(query, request)
 	if err != nil {
@@ -678,33 +2003,36 @@ func SearchSets(w http.ResponseWriter, r *http.Request) {
 	}
 
 	requestsTotal.Inc()
-	requestsSetsSearch.Inc()
+	requestsMonstersSearch.Inc()
 
 	if searchResp.EstimatedTotalHits == 0 {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
+	if page != nil && len(searchResp.Hits) == 0 { // page out of range
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
 	txn := Db.Txn(false)
 	defer txn.Abort()
 
-	var sets []APIListSet
+	var monsters []APIListMonster
 	for _, hit := range searchResp.Hits {
-		indexed := hit.(map[string]interface{})
-		itemId := int(indexed["id"].(float64))
+		monsterId := int(hit["id"].(float64))
 
-		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "sets"), "id", itemId)
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "monsters"), "id", monsterId)
 		if err != nil || raw == nil {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
 
-		item := raw.(*gen.MappedMultilangSet)
-		sets = append(sets, RenderSetListEntry(item, lang))
+		monster := raw.(*gen.MappedMultilangMonster)
+		monsters = append(monsters, RenderMonsterListEntry(monster, lang))
 	}
 
 	utils.WriteCacheHeader(&w)
-	err = json.NewEncoder(w).Encode(sets)
+	err = json.NewEncoder(w).Encode(RenderSearchResults(monsters, searchResp, page, nil, *r.URL))
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -712,7 +2040,6 @@ func SearchSets(w http.ResponseWriter, r *http.Request) {
 }
 
 func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Request) {
-	client := utils.CreateMeiliClient()
 	query := r.URL.Query().Get("query")
 	if query == "" {
 		w.WriteHeader(http.StatusBadRequest)
@@ -727,17 +2054,45 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	fieldFilters, err := utils.ParseFieldFilters(r.URL.Query(), itemFilterFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fieldFilterString, err := utils.MeiliFilterFromFieldFilters(fieldFilters, itemFilterFields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	effectFilterString, err := SearchEffectFilter(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	filterString = joinMeiliFilters(filterString, fieldFilterString, effectFilterString)
+
+	lang := r.Context().Value("lang").(string)
+
+	var searchLimit int64
+	if searchLimit, err = getLimitInBoundary(r.URL.Query().Get("limit")); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	page, err := ParseSearchPage(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
-	lang := r.Context().Value("lang").(string)
-
-	var searchLimit int64
-	if searchLimit, err = getLimitInBoundary(r.URL.Query().Get("limit")); err != nil {
+	facets, err := ParseSearchFacets(r.URL.Query().Get("facets"), itemSearchFacets)
+	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	index := client.Index(fmt.Sprintf("%s-all_items-%s", utils.CurrentRedBlueVersionStr(Version.Search), lang))
-	var request *meilisearch.SearchRequest
+	index := utils.Search.Index(fmt.Sprintf("%s-all_items-%s", utils.CurrentRedBlueVersionStr(Version.Search), lang))
+	var request *utils.SearchRequest
 	if all {
 		if filterTypeName != "" {
 			if filterString == "" {
@@ -763,16 +2118,20 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 	}
 
 	if filterString == "" {
-		request = &meilisearch.SearchRequest{
+		request = &utils.SearchRequest{
 			Limit: searchLimit,
 		}
 	} else {
-		request = &meilisearch.SearchRequest{
+		request = &utils.SearchRequest{
 			Limit:  searchLimit,
 			Filter: filterString,
 		}
 	}
 
+	request.Facets = facets
+
+	ApplySearchPage(request, page)
+
 	searchResp, err := index.Search(query, request)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -786,6 +2145,10 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
+	if page != nil && len(searchResp.Hits) == 0 { // page out of range
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
 	txn := Db.Txn(false)
 	defer txn.Abort()
@@ -793,8 +2156,7 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 	var items []APIListItem
 	var typedItems []APIListTypedItem
 	for _, hit := range searchResp.Hits {
-		indexed := hit.(map[string]interface{})
-		itemId := int(indexed["id"].(float64))
+		itemId := int(hit["id"].(float64))
 
 		var raw interface{}
 		if all {
@@ -815,13 +2177,15 @@ func SearchItems(itemType string, all bool, w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	utils.WriteCacheHeader(&w)
-	var encodeErr error
+	var results interface{}
 	if all {
-		encodeErr = json.NewEncoder(w).Encode(typedItems)
+		results = typedItems
 	} else {
-		encodeErr = json.NewEncoder(w).Encode(items)
+		results = items
 	}
+
+	utils.WriteCacheHeader(&w)
+	encodeErr := json.NewEncoder(w).Encode(RenderSearchResults(results, searchResp, page, facets, *r.URL))
 	if encodeErr != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -852,6 +2216,85 @@ func SearchAllItems(w http.ResponseWriter, r *http.Request) {
 	SearchItems("", true, w, r)
 }
 
+// almanax
+
+func GetAlmanaxHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	date, err := parseAlmanaxDate(r.URL.Query().Get("date"), time.Now())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	day := AlmanaxDayForDate(date, txn)
+	if day == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsAlmanax.Inc()
+
+	almanax := RenderAlmanax(day, date.Format(almanaxDateLayout), lang, txn)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(almanax)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetAlmanaxRangeHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	from, err := parseAlmanaxDate(r.URL.Query().Get("from"), time.Now())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	to, err := parseAlmanaxDate(r.URL.Query().Get("to"), from.AddDate(0, 0, 6))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	from = from.Truncate(24 * time.Hour)
+	to = to.Truncate(24 * time.Hour)
+	if to.Before(from) || to.Sub(from) >= almanaxMaxRangeDays*24*time.Hour {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	var almanax []APIAlmanax
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		day := AlmanaxDayForDate(date, txn)
+		if day == nil {
+			continue
+		}
+		almanax = append(almanax, RenderAlmanax(day, date.Format(almanaxDateLayout), lang, txn))
+	}
+
+	if len(almanax) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsAlmanax.Inc()
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(almanax)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
 // single
 
 func GetSingleSetHandler(w http.ResponseWriter, r *http.Request) {
@@ -905,6 +2348,231 @@ func GetSingleMountHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func GetSingleSpellHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "spells"), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsSpellsSingle.Inc()
+
+	spell := RenderSpell(raw.(*gen.MappedMultilangSpell), lang)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(spell)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetSingleMonsterHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "monsters"), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsMonstersSingle.Inc()
+
+	monster := RenderMonster(raw.(*gen.MappedMultilangMonster), lang)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(monster)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetSingleClassHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "classes"), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsClassesSingle.Inc()
+
+	class := RenderClass(raw.(*gen.MappedMultilangBreed), lang)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(class)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetSingleIdolHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "idols"), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsIdolsSingle.Inc()
+
+	idol := RenderIdol(raw.(*gen.MappedMultilangIdol), lang)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(idol)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetSingleCompanionHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "companions"), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsCompanionsSingle.Inc()
+
+	companion := RenderCompanion(raw.(*gen.MappedMultilangCompanion), lang, Db)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(companion)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetSingleAreaHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "areas"), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsAreasSingle.Inc()
+
+	area := RenderArea(raw.(*gen.MappedMultilangArea), lang)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(area)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetSingleSuperAreaHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "superareas"), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsSuperAreasSingle.Inc()
+
+	superArea := RenderSuperArea(raw.(*gen.MappedMultilangSuperArea), lang, Db)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(superArea)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetSingleNpcHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "npcs"), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsNpcsSingle.Inc()
+
+	npc := RenderNpc(raw.(*gen.MappedMultilangNpc), lang)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(npc)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func GetSingleJobHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.Context().Value("lang").(string)
+	ankamaId := r.Context().Value("ankamaId").(int)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "jobs"), "id", ankamaId)
+	if err != nil || raw == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsJobsSingle.Inc()
+
+	job := RenderJob(raw.(*gen.MappedMultilangJob), lang)
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(job)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
 func GetSingleItemWithOptionalRecipeHandler(itemType string, w http.ResponseWriter, r *http.Request) {
 	lang := r.Context().Value("lang").(string)
 	ankamaId := r.Context().Value("ankamaId").(int)
@@ -921,11 +2589,19 @@ func GetSingleItemWithOptionalRecipeHandler(itemType string, w http.ResponseWrit
 	requestsTotal.Inc()
 	requestsItemsSingle.Inc()
 
-	resource := RenderResource(raw.(*gen.MappedMultilangItem), lang)
+	item := raw.(*gen.MappedMultilangItem)
+	resource := RenderResource(item, lang)
 	recipe, exists := GetRecipeIfExists(ankamaId, txn)
 	if exists {
 		resource.Recipe = RenderRecipe(recipe, Db)
 	}
+	expansions := parseFields(strings.ToLower(r.URL.Query().Get("fields[item]")))
+	if expansions.Has("dropped_by") {
+		resource.DroppedBy = RenderDroppedBy(item, lang, Db)
+	}
+	if expansions.Has("used_in") {
+		resource.UsedIn = RenderUsedIn(item, lang, Db)
+	}
 	utils.WriteCacheHeader(&w)
 	err = json.NewEncoder(w).Encode(resource)
 	if err != nil {
@@ -967,12 +2643,19 @@ func GetSingleEquipmentHandler(w http.ResponseWriter, r *http.Request) {
 	requestsItemsSingle.Inc()
 
 	item := raw.(*gen.MappedMultilangItem)
+	expansions := parseFields(strings.ToLower(r.URL.Query().Get("fields[item]")))
 	if item.Type.SuperTypeId == 2 { // is weapon
 		weapon := RenderWeapon(item, lang)
 		recipe, exists := GetRecipeIfExists(ankamaId, txn)
 		if exists {
 			weapon.Recipe = RenderRecipe(recipe, Db)
 		}
+		if expansions.Has("dropped_by") {
+			weapon.DroppedBy = RenderDroppedBy(item, lang, Db)
+		}
+		if expansions.Has("used_in") {
+			weapon.UsedIn = RenderUsedIn(item, lang, Db)
+		}
 		utils.WriteCacheHeader(&w)
 		err = json.NewEncoder(w).Encode(weapon)
 		if err != nil {
@@ -985,6 +2668,12 @@ func GetSingleEquipmentHandler(w http.ResponseWriter, r *http.Request) {
 		if exists {
 			equipment.Recipe = RenderRecipe(recipe, Db)
 		}
+		if expansions.Has("dropped_by") {
+			equipment.DroppedBy = RenderDroppedBy(item, lang, Db)
+		}
+		if expansions.Has("used_in") {
+			equipment.UsedIn = RenderUsedIn(item, lang, Db)
+		}
 		utils.WriteCacheHeader(&w)
 		err = json.NewEncoder(w).Encode(equipment)
 		if err != nil {
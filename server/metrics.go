@@ -55,4 +55,154 @@ var (
 		Name: "dofus_requestsAllSetsSingle",
 		Help: "The total number of single set requests",
 	})
+
+	requestsSpellsSearch = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllSpellsSearch",
+		Help: "The total number of searched spells requests",
+	})
+
+	requestsSpellsList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllSpellsList",
+		Help: "The total number of list spells requests",
+	})
+
+	requestsSpellsSingle = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllSpellsSingle",
+		Help: "The total number of single spell requests",
+	})
+
+	requestsClassesList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllClassesList",
+		Help: "The total number of list classes requests",
+	})
+
+	requestsClassesSingle = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllClassesSingle",
+		Help: "The total number of single class requests",
+	})
+
+	requestsMonstersSearch = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllMonstersSearch",
+		Help: "The total number of searched monsters requests",
+	})
+
+	requestsMonstersList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllMonstersList",
+		Help: "The total number of list monsters requests",
+	})
+
+	requestsMonstersSingle = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllMonstersSingle",
+		Help: "The total number of single monster requests",
+	})
+
+	requestsAlmanax = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAlmanax",
+		Help: "The total number of almanax requests",
+	})
+
+	requestsIdolsList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllIdolsList",
+		Help: "The total number of list idols requests",
+	})
+
+	requestsIdolsSingle = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllIdolsSingle",
+		Help: "The total number of single idol requests",
+	})
+
+	requestsCompanionsSearch = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllCompanionsSearch",
+		Help: "The total number of searched companions requests",
+	})
+
+	requestsCompanionsList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllCompanionsList",
+		Help: "The total number of list companions requests",
+	})
+
+	requestsCompanionsSingle = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllCompanionsSingle",
+		Help: "The total number of single companion requests",
+	})
+
+	requestsAreasList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllAreasList",
+		Help: "The total number of list areas requests",
+	})
+
+	requestsAreasSingle = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllAreasSingle",
+		Help: "The total number of single area requests",
+	})
+
+	requestsSuperAreasList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllSuperAreasList",
+		Help: "The total number of list super areas requests",
+	})
+
+	requestsSuperAreasSingle = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllSuperAreasSingle",
+		Help: "The total number of single super area requests",
+	})
+
+	requestsNpcsList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllNpcsList",
+		Help: "The total number of list npcs requests",
+	})
+
+	requestsNpcsSingle = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllNpcsSingle",
+		Help: "The total number of single npc requests",
+	})
+
+	requestsJobsList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllJobsList",
+		Help: "The total number of list jobs requests",
+	})
+
+	requestsJobsSingle = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllJobsSingle",
+		Help: "The total number of single job requests",
+	})
+
+	requestsJobRecipesList = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllJobRecipesList",
+		Help: "The total number of list job recipes requests",
+	})
+
+	requestsItemsUsedIn = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllItemsUsedIn",
+		Help: "The total number of item used in requests",
+	})
+
+	requestsItemsRecipeTree = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsAllItemsRecipeTree",
+		Help: "The total number of item recipe tree requests",
+	})
+
+	requestsCraftCost = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsCraftCost",
+		Help: "The total number of craft cost requests",
+	})
+
+	requestsSetBonuses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsSetBonuses",
+		Help: "The total number of set bonus requests",
+	})
+
+	requestsBuildEvaluate = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsBuildEvaluate",
+		Help: "The total number of build evaluation requests",
+	})
+
+	requestsConditionEvaluate = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsConditionEvaluate",
+		Help: "The total number of item condition evaluation requests",
+	})
+
+	requestsSearch = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dofus_requestsSearch",
+		Help: "The total number of unified search requests",
+	})
 )
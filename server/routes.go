@@ -68,11 +68,15 @@ func Router() chi.Router {
 		})
 
 		r.With(languageChecker).Route("/{lang}", func(r chi.Router) {
+			r.Get("/search", SearchAll)
+
 			r.Route("/items", func(r chi.Router) {
 				r.Route("/consumables", func(r chi.Router) {
 					r.With(paginate).Get("/", ListConsumables)
 					r.With(disablePaginate).Get("/all", ListAllConsumables)
 					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleConsumableHandler)
+					r.With(ankamaIdExtractor, paginate).Get("/{ankamaId}/used-in", ListConsumablesUsedIn)
+					r.With(ankamaIdExtractor).Get("/{ankamaId}/recipe-tree", GetConsumableRecipeTree)
 					r.Get("/search", SearchConsumables)
 				})
 
@@ -80,6 +84,8 @@ func Router() chi.Router {
 					r.With(paginate).Get("/", ListResources)
 					r.With(disablePaginate).Get("/all", ListAllResources)
 					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleResourceHandler)
+					r.With(ankamaIdExtractor, paginate).Get("/{ankamaId}/used-in", ListResourcesUsedIn)
+					r.With(ankamaIdExtractor).Get("/{ankamaId}/recipe-tree", GetResourceRecipeTree)
 					r.Get("/search", SearchResources)
 				})
 
@@ -87,6 +93,8 @@ func Router() chi.Router {
 					r.With(paginate).Get("/", ListEquipment)
 					r.With(disablePaginate).Get("/all", ListAllEquipment)
 					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleEquipmentHandler)
+					r.With(ankamaIdExtractor, paginate).Get("/{ankamaId}/used-in", ListEquipmentUsedIn)
+					r.With(ankamaIdExtractor).Get("/{ankamaId}/recipe-tree", GetEquipmentRecipeTree)
 					r.Get("/search", SearchEquipment)
 				})
 
@@ -94,6 +102,8 @@ func Router() chi.Router {
 					r.With(paginate).Get("/", ListQuestItems)
 					r.With(disablePaginate).Get("/all", ListAllQuestItems)
 					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleQuestItemHandler)
+					r.With(ankamaIdExtractor, paginate).Get("/{ankamaId}/used-in", ListQuestItemsUsedIn)
+					r.With(ankamaIdExtractor).Get("/{ankamaId}/recipe-tree", GetQuestItemRecipeTree)
 					r.Get("/search", SearchQuestItems)
 				})
 
@@ -101,10 +111,14 @@ func Router() chi.Router {
 					r.With(paginate).Get("/", ListCosmetics)
 					r.With(disablePaginate).Get("/all", ListAllCosmetics)
 					r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleCosmeticHandler)
+					r.With(ankamaIdExtractor, paginate).Get("/{ankamaId}/used-in", ListCosmeticsUsedIn)
+					r.With(ankamaIdExtractor).Get("/{ankamaId}/recipe-tree", GetCosmeticRecipeTree)
 					r.Get("/search", SearchCosmetics)
 				})
 
 				r.Get("/search", SearchAllItems)
+				r.Post("/craft-cost", EstimateCraftCostHandler)
+				r.Post("/conditions/evaluate", EvaluateItemConditionsHandler)
 
 			})
 
@@ -119,8 +133,70 @@ func Router() chi.Router {
 				r.With(paginate).Get("/", ListSets)
 				r.With(disablePaginate).Get("/all", ListAllSets)
 				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleSetHandler)
+				r.Post("/bonuses", CalculateSetBonusesHandler)
 				r.Get("/search", SearchSets)
 			})
+
+			r.Route("/spells", func(r chi.Router) {
+				r.With(paginate).Get("/", ListSpells)
+				r.With(disablePaginate).Get("/all", ListAllSpells)
+				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleSpellHandler)
+				r.Get("/search", SearchSpells)
+			})
+
+			r.Route("/monsters", func(r chi.Router) {
+				r.With(paginate).Get("/", ListMonsters)
+				r.With(disablePaginate).Get("/all", ListAllMonsters)
+				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleMonsterHandler)
+				r.Get("/search", SearchMonsters)
+			})
+
+			r.Route("/jobs", func(r chi.Router) {
+				r.Get("/", ListJobs)
+				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleJobHandler)
+				r.With(ankamaIdExtractor, paginate).Get("/{ankamaId}/recipes", ListJobRecipes)
+			})
+
+			r.Route("/npcs", func(r chi.Router) {
+				r.With(paginate).Get("/", ListNpcs)
+				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleNpcHandler)
+			})
+
+			r.Route("/areas", func(r chi.Router) {
+				r.Get("/", ListAreas)
+				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleAreaHandler)
+			})
+
+			r.Route("/superareas", func(r chi.Router) {
+				r.Get("/", ListSuperAreas)
+				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleSuperAreaHandler)
+			})
+
+			r.Route("/companions", func(r chi.Router) {
+				r.With(paginate).Get("/", ListCompanions)
+				r.With(disablePaginate).Get("/all", ListAllCompanions)
+				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleCompanionHandler)
+				r.Get("/search", SearchCompanions)
+			})
+
+			r.Route("/idols", func(r chi.Router) {
+				r.With(paginate).Get("/", ListIdols)
+				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleIdolHandler)
+			})
+
+			r.Route("/almanax", func(r chi.Router) {
+				r.Get("/", GetAlmanaxHandler)
+				r.Get("/range", GetAlmanaxRangeHandler)
+			})
+
+			r.Route("/builds", func(r chi.Router) {
+				r.Post("/evaluate", EvaluateBuildHandler)
+			})
+
+			r.Route("/classes", func(r chi.Router) {
+				r.Get("/", ListClasses)
+				r.With(ankamaIdExtractor).Get("/{ankamaId}", GetSingleClassHandler)
+			})
 		})
 	})
 
@@ -0,0 +1,373 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dofusdude/api/gen"
+	"github.com/dofusdude/api/utils"
+	"github.com/hashicorp/go-memdb"
+)
+
+// searchEntity is a type that the unified search covers. Type is used for the type param and the hit tag,
+// Index is the name of the search index and the memdb table.
+type searchEntity struct {
+	Type   string
+	Index  string
+	Render func(raw interface{}, lang string) interface{}
+}
+
+var searchEntities = []searchEntity{
+	{Type: "items", Index: "all_items", Render: func(raw interface{}, lang string) interface{} {
+		return RenderTypedItemListEntry(raw.(*gen.MappedMultilangItem), lang)
+	}},
+	{Type: "sets", Index: "sets", Render: func(raw interface{}, lang string) interface{} {
+		return RenderSetListEntry(raw.(*gen.MappedMultilangSet), lang)
+	}},
+	{Type: "mounts", Index: "mounts", Render: func(raw interface{}, lang string) interface{} {
+		return RenderMountListEntry(raw.(*gen.MappedMultilangMount), lang)
+	}},
+	{Type: "spells", Index: "spells", Render: func(raw interface{}, lang string) interface{} {
+		return RenderSpellListEntry(raw.(*gen.MappedMultilangSpell), lang)
+	}},
+	{Type: "monsters", Index: "monsters", Render: func(raw interface{}, lang string) interface{} {
+		return RenderMonsterListEntry(raw.(*gen.MappedMultilangMonster), lang)
+	}},
+	{Type: "companions", Index: "companions", Render: func(raw interface{}, lang string) interface{} {
+		return RenderCompanionListEntry(raw.(*gen.MappedMultilangCompanion), lang)
+	}},
+}
+
+type APISearchHit struct {
+	Type     string      `json:"type"`
+	AnkamaId int         `json:"ankama_id"`
+	Name     string      `json:"name"`
+	Result   interface{} `json:"result"`
+
+	tier     int
+	position int
+}
+
+type APISearchResults struct {
+	Hits []APISearchHit `json:"hits"`
+}
+
+// itemSearchFacets are the attributes of the item index that can be requested with the facets param.
+var itemSearchFacets = map[string]bool{
+	"type_name":  true,
+	"super_type": true,
+	"level":      true,
+}
+
+const (
+	searchLevelBucketSize = 10
+	searchDefaultPageSize = 16
+	searchMaxPageSize     = 100
+)
+
+// APISearchPage is returned instead of the plain list when pages or facets are requested.
+type APISearchPage struct {
+	Links             *utils.PaginationLinks      `json:"_links,omitempty"`
+	Items             interface{}                 `json:"items"`
+	TotalHits         int64                       `json:"total_hits"`
+	FacetDistribution map[string]map[string]int64 `json:"facet_distribution,omitempty"`
+}
+
+// ParseSearchPage reads page[number] and page[size] of a search. Without them the search keeps using the
+// limit param and answers with a plain list, so the returned pagination is nil.
+func ParseSearchPage(query url.Values) (*utils.Pagination, error) {
+	pageNumStr := query.Get("page[number]")
+	pageSizeStr := query.Get("page[size]")
+	if pageNumStr == "" && pageSizeStr == "" {
+		return nil, nil
+	}
+
+	page := utils.Pagination{PageNumber: 1, PageSize: searchDefaultPageSize}
+	var err error
+	if pageNumStr != "" {
+		if page.PageNumber, err = strconv.Atoi(pageNumStr); err != nil || page.PageNumber <= 0 {
+			return nil, fmt.Errorf("invalid page number")
+		}
+	}
+	if pageSizeStr != "" {
+		if page.PageSize, err = strconv.Atoi(pageSizeStr); err != nil || page.PageSize <= 0 || page.PageSize > searchMaxPageSize {
+			return nil, fmt.Errorf("invalid page size")
+		}
+	}
+	return &page, nil
+}
+
+// ApplySearchPage replaces the limit of the request with the requested page, so the backend counts the exact
+// total hits instead of estimating them.
+func ApplySearchPage(request *utils.SearchRequest, page *utils.Pagination) {
+	if page == nil {
+		return
+	}
+	request.Page = int64(page.PageNumber)
+	request.HitsPerPage = int64(page.PageSize)
+}
+
+// RenderSearchResults wraps the rendered hits with links, total hits and facets when pages or facets are requested.
+func RenderSearchResults(items interface{}, searchResp *utils.SearchResponse, page *utils.Pagination, facets []string, requestUrl url.URL) interface{} {
+	if page == nil && len(facets) == 0 {
+		return items
+	}
+
+	results := APISearchPage{
+		Items:     items,
+		TotalHits: searchResp.EstimatedTotalHits,
+	}
+	if page != nil {
+		results.TotalHits = searchResp.TotalHits
+		links, _ := page.BuildLinks(requestUrl, int(searchResp.TotalHits))
+		results.Links = &links
+	}
+	if len(facets) > 0 {
+		results.FacetDistribution = RenderFacetDistribution(searchResp.FacetDistribution)
+	}
+	return results
+}
+
+// ParseSearchFacets reads the comma separated facets param. Unknown attributes are an error.
+func ParseSearchFacets(facetsParam string, allowed map[string]bool) ([]string, error) {
+	if facetsParam == "" {
+		return nil, nil
+	}
+
+	var facets []string
+	for _, facet := range strings.Split(facetsParam, ",") {
+		facet = strings.TrimSpace(strings.ToLower(facet))
+		if !allowed[facet] {
+			return nil, fmt.Errorf("unknown facet %s", facet)
+		}
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
+// levelBucket groups a level into ranges of ten, like "1-10" or "191-200".
+func levelBucket(level int) string {
+	if level <= 0 {
+		return "0"
+	}
+	start := (level-1)/searchLevelBucketSize*searchLevelBucketSize + 1
+	return fmt.Sprintf("%d-%d", start, start+searchLevelBucketSize-1)
+}
+
+// RenderFacetDistribution sums the per level counts of the backend into level buckets.
+func RenderFacetDistribution(distribution map[string]map[string]int64) map[string]map[string]int64 {
+	rendered := make(map[string]map[string]int64)
+	for attribute, values := range distribution {
+		if attribute != "level" {
+			rendered[attribute] = values
+			continue
+		}
+
+		buckets := make(map[string]int64)
+		for value, count := range values {
+			level, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			buckets[levelBucket(level)] += count
+		}
+		rendered[attribute] = buckets
+	}
+	return rendered
+}
+
+// SearchEffectFilter builds the search filter of the repeatable filter[effect] param. Numbers are element ids
+// like in the item lists, everything else is compared with the effect names in the language of the index.
+// Value bounds are only available in the lists because the index does not hold effect values.
+func SearchEffectFilter(query url.Values) (string, error) {
+	if len(query["filter[effect_min]"]) > 0 || len(query["filter[effect_max]"]) > 0 {
+		return "", fmt.Errorf("effect bounds not supported in search")
+	}
+
+	var parts []string
+	for _, effect := range query["filter[effect]"] {
+		effect = strings.TrimSpace(effect)
+		if effect == "" {
+			return "", fmt.Errorf("filter[effect] empty")
+		}
+		if elementId, err := strconv.Atoi(effect); err == nil {
+			parts = append(parts, fmt.Sprintf("effect_element_ids = %d", elementId))
+			continue
+		}
+		name := strings.ReplaceAll(strings.ToLower(effect), `"`, `\"`)
+		parts = append(parts, fmt.Sprintf(`effects = "%s"`, name))
+	}
+	return strings.Join(parts, " AND "), nil
+}
+
+// ParseSearchTypes reads the comma separated types param. All types are searched when it is empty.
+func ParseSearchTypes(typesParam string) ([]searchEntity, error) {
+	if typesParam == "" {
+		return searchEntities, nil
+	}
+
+	var entities []searchEntity
+	for _, entityType := range strings.Split(typesParam, ",") {
+		entityType = strings.TrimSpace(strings.ToLower(entityType))
+		found := false
+		for _, entity := range searchEntities {
+			if entity.Type == entityType {
+				entities = append(entities, entity)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown search type %s", entityType)
+		}
+	}
+	return entities, nil
+}
+
+// ParseSearchLimits reads limit as the default for every type and limit[<type>] to override single types.
+func ParseSearchLimits(r *http.Request, entities []searchEntity) (map[string]int64, error) {
+	defaultLimit, err := getLimitInBoundary(r.URL.Query().Get("limit"))
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[string]int64)
+	for _, entity := range entities {
+		limits[entity.Type] = defaultLimit
+		typeLimit := r.URL.Query().Get(fmt.Sprintf("limit[%s]", entity.Type))
+		if typeLimit == "" {
+			continue
+		}
+		if limits[entity.Type], err = getLimitInBoundary(typeLimit); err != nil {
+			return nil, err
+		}
+	}
+	return limits, nil
+}
+
+// searchNameTier compares the name of a hit with the query, so hits of different types can be ranked together.
+// Exact names come first, then names starting with the query, then names with a word starting with it.
+func searchNameTier(name string, query string) int {
+	nameKey := strings.Join(utils.SearchTokens(name), " ")
+	queryKey := strings.Join(utils.SearchTokens(query), " ")
+	switch {
+	case nameKey == queryKey:
+		return 0
+	case strings.HasPrefix(nameKey, queryKey):
+		return 1
+	case strings.Contains(nameKey, " "+queryKey):
+		return 2
+	}
+	return 3
+}
+
+// UnifiedSearch queries the index of every entity and merges the hits. Hits keep the order of their own index
+// within the same name tier, ties between types are broken by the order of the entities.
+// The merged hits have no pages and no total, the limits bound every type on its own. Pages are available from
+// the search endpoint of a single type. A failing index is logged and skipped, the error is only returned when
+// every searched index failed.
+func UnifiedSearch(query string, lang string, entities []searchEntity, limits map[string]int64, txn *memdb.Txn) ([]APISearchHit, error) {
+	hits := make([]APISearchHit, 0)
+	searched, failed := 0, 0
+	var searchErr error
+	for _, entity := range entities {
+		if limits[entity.Type] == 0 {
+			continue
+		}
+		searched++
+
+		index := utils.Search.Index(fmt.Sprintf("%s-%s-%s", utils.CurrentRedBlueVersionStr(Version.Search), entity.Index, lang))
+		searchResp, err := index.Search(query, &utils.SearchRequest{
+			Limit: limits[entity.Type],
+		})
+		if err != nil {
+			log.Println(err)
+			failed++
+			searchErr = err
+			continue
+		}
+
+		for position, hit := range searchResp.Hits {
+			ankamaId := int(hit["id"].(float64))
+			raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), entity.Index), "id", ankamaId)
+			if err != nil || raw == nil {
+				continue
+			}
+
+			name, _ := hit["name"].(string)
+			hits = append(hits, APISearchHit{
+				Type:     entity.Type,
+				AnkamaId: ankamaId,
+				Name:     name,
+				Result:   entity.Render(raw, lang),
+				tier:     searchNameTier(name, query),
+				position: position,
+			})
+		}
+	}
+
+	if searched > 0 && failed == searched {
+		return nil, searchErr
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].tier != hits[j].tier {
+			return hits[i].tier < hits[j].tier
+		}
+		return hits[i].position < hits[j].position
+	})
+
+	return hits, nil
+}
+
+func SearchAll(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	entities, err := ParseSearchTypes(r.URL.Query().Get("types"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	limits, err := ParseSearchLimits(r, entities)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	lang := r.Context().Value("lang").(string)
+
+	txn := Db.Txn(false)
+	defer txn.Abort()
+
+	hits, err := UnifiedSearch(query, lang, entities, limits, txn)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	requestsTotal.Inc()
+	requestsSearch.Inc()
+
+	if len(hits) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	utils.WriteCacheHeader(&w)
+	err = json.NewEncoder(w).Encode(APISearchResults{Hits: hits})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
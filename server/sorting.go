@@ -0,0 +1,121 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/dofusdude/api/gen"
+	"github.com/dofusdude/api/utils"
+)
+
+// sortEntry keeps the effect values that the sort keys need next to the entry, so they are computed
+// once per entry and not in every comparison.
+type sortEntry[T any] struct {
+	entry        T
+	effectValues map[int]int
+}
+
+// sortByKeys sorts stable by the keys. Effect keys compare the highest possible value of the element,
+// entries without it sort as 0. All other keys are handled by compare.
+func sortByKeys[T any](entries []T, keys []utils.SortKey, effects func(entry T) []gen.MappedMultilangEffect, compare func(a, b T, key utils.SortKey) int) {
+	if len(keys) == 0 {
+		return
+	}
+
+	var effectIds []int
+	for _, key := range keys {
+		if key.Field == "effect" {
+			effectIds = append(effectIds, key.ElementId)
+		}
+	}
+
+	sorted := make([]sortEntry[T], len(entries))
+	for i, entry := range entries {
+		sorted[i].entry = entry
+		if len(effectIds) == 0 {
+			continue
+		}
+		sums := make(map[int]*APISummedEffect)
+		SumEffects(effects(entry), "en", sums)
+		sorted[i].effectValues = make(map[int]int, len(effectIds))
+		for _, elementId := range effectIds {
+			if sum, ok := sums[elementId]; ok {
+				sorted[i].effectValues[elementId] = sum.MaxInt
+			}
+		}
+	}
+
+	sort.SliceStable(sorted, utils.LessByKeys(keys, func(i, j int, key utils.SortKey) int {
+		if key.Field == "effect" {
+			return utils.CompareInt(sorted[i].effectValues[key.ElementId], sorted[j].effectValues[key.ElementId])
+		}
+		return compare(sorted[i].entry, sorted[j].entry, key)
+	}))
+
+	for i := range sorted {
+		entries[i] = sorted[i].entry
+	}
+}
+
+var itemSortFields = []string{"level", "name", "pods", "ankama_id", "type_name", "effect"}
+var setSortFields = []string{"level", "name", "ankama_id", "item_count", "effect"}
+var mountSortFields = []string{"name", "ankama_id", "family_name", "effect"}
+
+func SortItems(items []*gen.MappedMultilangItem, keys []utils.SortKey, lang string) {
+	sortByKeys(items, keys, func(item *gen.MappedMultilangItem) []gen.MappedMultilangEffect {
+		return item.Effects
+	}, func(a, b *gen.MappedMultilangItem, key utils.SortKey) int {
+		switch key.Field {
+		case "level":
+			return utils.CompareInt(a.Level, b.Level)
+		case "name":
+			return utils.CompareCollated(a.Name[lang], b.Name[lang])
+		case "pods":
+			return utils.CompareInt(a.Pods, b.Pods)
+		case "ankama_id":
+			return utils.CompareInt(a.AnkamaId, b.AnkamaId)
+		case "type_name":
+			return utils.CompareCollated(a.Type.Name[lang], b.Type.Name[lang])
+		}
+		return 0
+	})
+}
+
+// setFullBonus returns the bonus of the highest tier, which is what players compare sets by.
+func setFullBonus(set *gen.MappedMultilangSet) []gen.MappedMultilangEffect {
+	if len(set.Effects) == 0 {
+		return nil
+	}
+	return set.Effects[len(set.Effects)-1]
+}
+
+func SortSets(sets []*gen.MappedMultilangSet, keys []utils.SortKey, lang string) {
+	sortByKeys(sets, keys, setFullBonus, func(a, b *gen.MappedMultilangSet, key utils.SortKey) int {
+		switch key.Field {
+		case "level":
+			return utils.CompareInt(a.Level, b.Level)
+		case "name":
+			return utils.CompareCollated(a.Name[lang], b.Name[lang])
+		case "ankama_id":
+			return utils.CompareInt(a.AnkamaId, b.AnkamaId)
+		case "item_count":
+			return utils.CompareInt(len(a.ItemIds), len(b.ItemIds))
+		}
+		return 0
+	})
+}
+
+func SortMounts(mounts []*gen.MappedMultilangMount, keys []utils.SortKey, lang string) {
+	sortByKeys(mounts, keys, func(mount *gen.MappedMultilangMount) []gen.MappedMultilangEffect {
+		return mount.Effects
+	}, func(a, b *gen.MappedMultilangMount, key utils.SortKey) int {
+		switch key.Field {
+		case "name":
+			return utils.CompareCollated(a.Name[lang], b.Name[lang])
+		case "ankama_id":
+			return utils.CompareInt(a.AnkamaId, b.AnkamaId)
+		case "family_name":
+			return utils.CompareCollated(a.FamilyName[lang], b.FamilyName[lang])
+		}
+		return 0
+	})
+}
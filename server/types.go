@@ -6,6 +6,7 @@ import (
 	"github.com/dofusdude/api/utils"
 	"github.com/hashicorp/go-memdb"
 	"log"
+	"sort"
 )
 
 type ApiImageUrls struct {
@@ -70,22 +71,27 @@ func RenderEffects(effects *[]gen.MappedMultilangEffect, lang string) []ApiEffec
 }
 
 type ApiCondition struct {
-	Operator string           `json:"operator"`
-	IntValue int              `json:"int_value"`
-	Element  ApiConditionType `json:"element"`
+	Operator    string           `json:"operator"`
+	IntValue    int              `json:"int_value"`
+	Element     ApiConditionType `json:"element"`
+	AreaId      *int             `json:"area_ankama_id,omitempty"`
+	ReferenceId *int             `json:"reference_ankama_id,omitempty"`
 }
 
 type APIResource struct {
-	Id          int            `json:"ankama_id"`
-	Name        string         `json:"name"`
-	Description string         `json:"description"`
-	Type        ApiType        `json:"type"`
-	Level       int            `json:"level"`
-	Pods        int            `json:"pods"`
-	ImageUrls   ApiImageUrls   `json:"image_urls,omitempty"`
-	Effects     []ApiEffect    `json:"effects,omitempty"`
-	Conditions  []ApiCondition `json:"conditions,omitempty"`
-	Recipe      []APIRecipe    `json:"recipe,omitempty"`
+	Id             int                `json:"ankama_id"`
+	Name           string             `json:"name"`
+	Description    string             `json:"description"`
+	Type           ApiType            `json:"type"`
+	Level          int                `json:"level"`
+	Pods           int                `json:"pods"`
+	ImageUrls      ApiImageUrls       `json:"image_urls,omitempty"`
+	Effects        []ApiEffect        `json:"effects,omitempty"`
+	Conditions     []ApiCondition     `json:"conditions,omitempty"`
+	ConditionsTree *ApiConditionNode  `json:"conditions_tree,omitempty"`
+	Recipe         []APIRecipe        `json:"recipe,omitempty"`
+	DroppedBy      []APIDropSource    `json:"dropped_by,omitempty"`
+	UsedIn         []APIListTypedItem `json:"used_in,omitempty"`
 }
 
 func RenderResource(item *gen.MappedMultilangItem, lang string) APIResource {
@@ -109,6 +115,8 @@ func RenderResource(item *gen.MappedMultilangItem, lang string) APIResource {
 	} else {
 		resource.Conditions = conditions
 	}
+	// the tree also holds the unknown criteria, which are missing in the flat list
+	resource.ConditionsTree = RenderConditionTree(item.ConditionsTree, lang)
 
 	effects := RenderEffects(&item.Effects, lang)
 	if len(effects) == 0 {
@@ -121,18 +129,21 @@ func RenderResource(item *gen.MappedMultilangItem, lang string) APIResource {
 }
 
 type APIEquipment struct {
-	Id          int                `json:"ankama_id"`
-	Name        string             `json:"name"`
-	Description string             `json:"description"`
-	Type        ApiType            `json:"type"`
-	IsWeapon    bool               `json:"is_weapon"`
-	Level       int                `json:"level"`
-	Pods        int                `json:"pods"`
-	ImageUrls   ApiImageUrls       `json:"image_urls,omitempty"`
-	Effects     []ApiEffect        `json:"effects,omitempty"`
-	Conditions  []ApiCondition     `json:"conditions,omitempty"`
-	Recipe      []APIRecipe        `json:"recipe,omitempty"`
-	ParentSet   *APISetReverseLink `json:"parent_set,omitempty"`
+	Id             int                `json:"ankama_id"`
+	Name           string             `json:"name"`
+	Description    string             `json:"description"`
+	Type           ApiType            `json:"type"`
+	IsWeapon       bool               `json:"is_weapon"`
+	Level          int                `json:"level"`
+	Pods           int                `json:"pods"`
+	ImageUrls      ApiImageUrls       `json:"image_urls,omitempty"`
+	Effects        []ApiEffect        `json:"effects,omitempty"`
+	Conditions     []ApiCondition     `json:"conditions,omitempty"`
+	ConditionsTree *ApiConditionNode  `json:"conditions_tree,omitempty"`
+	Recipe         []APIRecipe        `json:"recipe,omitempty"`
+	ParentSet      *APISetReverseLink `json:"parent_set,omitempty"`
+	DroppedBy      []APIDropSource    `json:"dropped_by,omitempty"`
+	UsedIn         []APIListTypedItem `json:"used_in,omitempty"`
 }
 
 func RenderEquipment(item *gen.MappedMultilangItem, lang string) APIEquipment {
@@ -166,6 +177,7 @@ func RenderEquipment(item *gen.MappedMultilangItem, lang string) APIEquipment {
 	} else {
 		equip.Conditions = conditions
 	}
+	equip.ConditionsTree = RenderConditionTree(item.ConditionsTree, lang)
 
 	effects := RenderEffects(&item.Effects, lang)
 	if len(effects) == 0 {
@@ -198,6 +210,7 @@ type APIWeapon struct {
 	ImageUrls              ApiImageUrls       `json:"image_urls,omitempty"`
 	Effects                []ApiEffect        `json:"effects,omitempty"`
 	Conditions             []ApiCondition     `json:"conditions,omitempty"`
+	ConditionsTree         *ApiConditionNode  `json:"conditions_tree,omitempty"`
 	CriticalHitProbability int                `json:"critical_hit_probability"`
 	CriticalHitBonus       int                `json:"critical_hit_bonus"`
 	TwoHanded              bool               `json:"is_two_handed"`
@@ -206,6 +219,8 @@ type APIWeapon struct {
 	Range                  APIRange           `json:"range"`
 	Recipe                 []APIRecipe        `json:"recipe,omitempty"`
 	ParentSet              *APISetReverseLink `json:"parent_set,omitempty"`
+	DroppedBy              []APIDropSource    `json:"dropped_by,omitempty"`
+	UsedIn                 []APIListTypedItem `json:"used_in,omitempty"`
 }
 
 func RenderWeapon(item *gen.MappedMultilangItem, lang string) APIWeapon {
@@ -248,6 +263,7 @@ func RenderWeapon(item *gen.MappedMultilangItem, lang string) APIWeapon {
 	} else {
 		weapon.Conditions = conditions
 	}
+	weapon.ConditionsTree = RenderConditionTree(item.ConditionsTree, lang)
 
 	effects := RenderEffects(&item.Effects, lang)
 	if len(effects) == 0 {
@@ -266,17 +282,23 @@ type MappedMultilangCondition struct {
 	Templated map[string]string `json:"templated"`
 }
 
+func RenderCondition(condition *gen.MappedMultilangCondition, lang string) ApiCondition {
+	return ApiCondition{
+		Operator: condition.Operator,
+		IntValue: condition.Value,
+		Element: ApiConditionType{
+			Name: condition.Templated[lang],
+			Id:   condition.ElementId,
+		},
+		AreaId:      condition.AreaId,
+		ReferenceId: condition.ReferenceId,
+	}
+}
+
 func RenderConditions(conditions *[]gen.MappedMultilangCondition, lang string) []ApiCondition {
 	var retConditions []ApiCondition
-	for _, condition := range *conditions {
-		retConditions = append(retConditions, ApiCondition{
-			Operator: condition.Operator,
-			IntValue: condition.Value,
-			Element: ApiConditionType{
-				Name: condition.Templated[lang],
-				Id:   condition.ElementId,
-			},
-		})
+	for i := range *conditions {
+		retConditions = append(retConditions, RenderCondition(&(*conditions)[i], lang))
 	}
 
 	if len(retConditions) > 0 {
@@ -286,6 +308,33 @@ func RenderConditions(conditions *[]gen.MappedMultilangCondition, lang string) [
 	return nil
 }
 
+type ApiConditionNode struct {
+	Relation  string             `json:"relation,omitempty"`
+	Condition *ApiCondition      `json:"condition,omitempty"`
+	Unknown   string             `json:"unknown,omitempty"`
+	Children  []ApiConditionNode `json:"children,omitempty"`
+}
+
+func RenderConditionTree(node *gen.MappedMultilangConditionNode, lang string) *ApiConditionNode {
+	if node == nil {
+		return nil
+	}
+
+	res := ApiConditionNode{
+		Relation: node.Relation,
+		Unknown:  node.Unknown,
+	}
+	if node.Condition != nil {
+		condition := RenderCondition(node.Condition, lang)
+		res.Condition = &condition
+	}
+	for i := range node.Children {
+		res.Children = append(res.Children, *RenderConditionTree(&node.Children[i], lang))
+	}
+
+	return &res
+}
+
 type ApiType struct {
 	Name string `json:"name"`
 	Id   int    `json:"id"`
@@ -311,10 +360,13 @@ type APIListItem struct {
 	ImageUrls ApiImageUrls `json:"image_urls,omitempty"`
 
 	// extra fields
-	Description *string        `json:"description,omitempty"`
-	Recipe      []APIRecipe    `json:"recipe,omitempty"`
-	Conditions  []ApiCondition `json:"conditions,omitempty"`
-	Effects     []ApiEffect    `json:"effects,omitempty"`
+	Description    *string            `json:"description,omitempty"`
+	Recipe         []APIRecipe        `json:"recipe,omitempty"`
+	Conditions     []ApiCondition     `json:"conditions,omitempty"`
+	ConditionsTree *ApiConditionNode  `json:"conditions_tree,omitempty"`
+	Effects        []ApiEffect        `json:"effects,omitempty"`
+	DroppedBy      []APIDropSource    `json:"dropped_by,omitempty"`
+	UsedIn         []APIListTypedItem `json:"used_in,omitempty"`
 
 	// extra equipment
 	IsWeapon  *bool              `json:"is_weapon,omitempty"`
@@ -422,6 +474,11 @@ type APIPageItem struct {
 	Items []APIListItem         `json:"items"`
 }
 
+type APIPageTypedItem struct {
+	Links utils.PaginationLinks `json:"_links,omitempty"`
+	Items []APIListTypedItem    `json:"items"`
+}
+
 type APIPageMount struct {
 	Links utils.PaginationLinks `json:"_links,omitempty"`
 	Items []APIListMount        `json:"mounts"`
@@ -508,3 +565,956 @@ func RenderSet(set *gen.MappedMultilangSet, lang string) APISet {
 
 	return resSet
 }
+
+type APIPageSpell struct {
+	Links utils.PaginationLinks `json:"_links,omitempty"`
+	Items []APIListSpell        `json:"spells"`
+}
+
+type APISpellType struct {
+	Id        int    `json:"id"`
+	Name      string `json:"name"`
+	ShortName string `json:"short_name"`
+}
+
+type APIListSpell struct {
+	Id        int          `json:"ankama_id"`
+	Name      string       `json:"name"`
+	Type      APISpellType `json:"type"`
+	IconId    int          `json:"icon_id"`
+	ImageUrls ApiImageUrls `json:"image_urls,omitempty"`
+
+	// extra fields
+	Description *string `json:"description,omitempty"`
+	SpellLevels []int   `json:"spell_level_ids,omitempty"`
+}
+
+func RenderSpellListEntry(spell *gen.MappedMultilangSpell, lang string) APIListSpell {
+	return APIListSpell{
+		Id:   spell.AnkamaId,
+		Name: spell.Name[lang],
+		Type: APISpellType{
+			Id:        spell.Type.Id,
+			Name:      spell.Type.LongName[lang],
+			ShortName: spell.Type.ShortName[lang],
+		},
+		IconId:    spell.IconId,
+		ImageUrls: ApiImageUrls{Icon: spell.Image},
+	}
+}
+
+type APISpell struct {
+	Id          int          `json:"ankama_id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Type        APISpellType `json:"type"`
+	Order       int          `json:"order"`
+	IconId      int          `json:"icon_id"`
+	ImageUrls   ApiImageUrls `json:"image_urls,omitempty"`
+	SpellLevels []int        `json:"spell_level_ids"`
+}
+
+func RenderSpell(spell *gen.MappedMultilangSpell, lang string) APISpell {
+	return APISpell{
+		Id:          spell.AnkamaId,
+		Name:        spell.Name[lang],
+		Description: spell.Description[lang],
+		Type: APISpellType{
+			Id:        spell.Type.Id,
+			Name:      spell.Type.LongName[lang],
+			ShortName: spell.Type.ShortName[lang],
+		},
+		Order:       spell.Order,
+		IconId:      spell.IconId,
+		ImageUrls:   ApiImageUrls{Icon: spell.Image},
+		SpellLevels: spell.SpellLevels,
+	}
+}
+
+type APIListClass struct {
+	Id       int    `json:"ankama_id"`
+	Name     string `json:"name"`
+	LongName string `json:"long_name"`
+
+	// extra fields
+	Description *string        `json:"description,omitempty"`
+	Spells      []APIListSpell `json:"spells,omitempty"`
+}
+
+func RenderClassListEntry(breed *gen.MappedMultilangBreed, lang string) APIListClass {
+	return APIListClass{
+		Id:       breed.AnkamaId,
+		Name:     breed.ShortName[lang],
+		LongName: breed.LongName[lang],
+	}
+}
+
+type APIClass struct {
+	Id                  int            `json:"ankama_id"`
+	Name                string         `json:"name"`
+	LongName            string         `json:"long_name"`
+	Description         string         `json:"description"`
+	GameplayDescription string         `json:"gameplay_description"`
+	Spells              []APIListSpell `json:"spells,omitempty"`
+}
+
+func RenderClassSpells(breed *gen.MappedMultilangBreed, lang string, db *memdb.MemDB) []APIListSpell {
+	if len(breed.SpellIds) == 0 {
+		return nil
+	}
+
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	var spells []APIListSpell
+	for _, spellId := range breed.SpellIds {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "spells"), "id", spellId)
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+		if raw == nil {
+			continue
+		}
+		spells = append(spells, RenderSpellListEntry(raw.(*gen.MappedMultilangSpell), lang))
+	}
+	return spells
+}
+
+func RenderClass(breed *gen.MappedMultilangBreed, lang string) APIClass {
+	return APIClass{
+		Id:                  breed.AnkamaId,
+		Name:                breed.ShortName[lang],
+		LongName:            breed.LongName[lang],
+		Description:         breed.Description[lang],
+		GameplayDescription: breed.GameplayDescription[lang],
+		Spells:              RenderClassSpells(breed, lang, Db),
+	}
+}
+
+type APIPageMonster struct {
+	Links utils.PaginationLinks `json:"_links,omitempty"`
+	Items []APIListMonster      `json:"monsters"`
+}
+
+type APIMonsterGrade struct {
+	Grade          int `json:"grade"`
+	Level          int `json:"level"`
+	LifePoints     int `json:"life_points"`
+	ActionPoints   int `json:"action_points"`
+	MovementPoints int `json:"movement_points"`
+	ApDodge        int `json:"ap_dodge"`
+	MpDodge        int `json:"mp_dodge"`
+	Wisdom         int `json:"wisdom"`
+	Experience     int `json:"experience"`
+	Resistances    struct {
+		Neutral int `json:"neutral"`
+		Earth   int `json:"earth"`
+		Fire    int `json:"fire"`
+		Water   int `json:"water"`
+		Air     int `json:"air"`
+	} `json:"resistances"`
+}
+
+func RenderMonsterGrades(monster *gen.MappedMultilangMonster) []APIMonsterGrade {
+	var grades []APIMonsterGrade
+	for _, grade := range monster.Grades {
+		apiGrade := APIMonsterGrade{
+			Grade:          grade.Grade,
+			Level:          grade.Level,
+			LifePoints:     grade.LifePoints,
+			ActionPoints:   grade.ActionPoints,
+			MovementPoints: grade.MovementPoints,
+			ApDodge:        grade.ApDodge,
+			MpDodge:        grade.MpDodge,
+			Wisdom:         grade.Wisdom,
+			Experience:     grade.Experience,
+		}
+		apiGrade.Resistances.Neutral = grade.NeutralResistance
+		apiGrade.Resistances.Earth = grade.EarthResistance
+		apiGrade.Resistances.Fire = grade.FireResistance
+		apiGrade.Resistances.Water = grade.WaterResistance
+		apiGrade.Resistances.Air = grade.AirResistance
+		grades = append(grades, apiGrade)
+	}
+	return grades
+}
+
+func MonsterLevelRange(monster *gen.MappedMultilangMonster) APIRange {
+	var levels APIRange
+	for i, grade := range monster.Grades {
+		if i == 0 || grade.Level < levels.Min {
+			levels.Min = grade.Level
+		}
+		if grade.Level > levels.Max {
+			levels.Max = grade.Level
+		}
+	}
+	return levels
+}
+
+type APIMonsterDrop struct {
+	AnkamaId           int          `json:"item_ankama_id"`
+	Name               string       `json:"name"`
+	ItemType           string       `json:"item_subtype"`
+	ImageUrls          ApiImageUrls `json:"image_urls,omitempty"`
+	Count              int          `json:"count"`
+	PercentagesByGrade []float64    `json:"percentages_by_grade"`
+	HasCriteria        bool         `json:"has_criteria"`
+}
+
+func RenderMonsterDrops(monster *gen.MappedMultilangMonster, lang string, db *memdb.MemDB) []APIMonsterDrop {
+	if len(monster.Drops) == 0 {
+		return nil
+	}
+
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	var drops []APIMonsterDrop
+	for _, drop := range monster.Drops {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", drop.ItemId)
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+		if raw == nil {
+			continue // hidden item
+		}
+		item := raw.(*gen.MappedMultilangItem)
+
+		drops = append(drops, APIMonsterDrop{
+			AnkamaId:           drop.ItemId,
+			Name:               item.Name[lang],
+			ItemType:           utils.CategoryIdApiMapping(item.Type.CategoryId),
+			ImageUrls:          RenderImageUrls(utils.ImageUrls(item.IconId, "item")),
+			Count:              drop.Count,
+			PercentagesByGrade: drop.Percentages,
+			HasCriteria:        drop.HasCriteria,
+		})
+	}
+	return drops
+}
+
+type APIDropSource struct {
+	AnkamaId           int          `json:"monster_ankama_id"`
+	Name               string       `json:"name"`
+	Level              APIRange     `json:"level"`
+	ImageUrls          ApiImageUrls `json:"image_urls,omitempty"`
+	PercentagesByGrade []float64    `json:"percentages_by_grade,omitempty"`
+}
+
+func RenderUsedIn(item *gen.MappedMultilangItem, lang string, db *memdb.MemDB) []APIListTypedItem {
+	if len(item.UsedInRecipes) == 0 {
+		return nil
+	}
+
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	var usedIn []APIListTypedItem
+	for _, resultId := range item.UsedInRecipes {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", resultId)
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+		if raw == nil {
+			continue
+		}
+		usedIn = append(usedIn, RenderTypedItemListEntry(raw.(*gen.MappedMultilangItem), lang))
+	}
+	return usedIn
+}
+
+func RenderDroppedBy(item *gen.MappedMultilangItem, lang string, db *memdb.MemDB) []APIDropSource {
+	if len(item.DropMonsterIds) == 0 {
+		return nil
+	}
+
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	var sources []APIDropSource
+	for _, monsterId := range item.DropMonsterIds {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "monsters"), "id", monsterId)
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+		if raw == nil {
+			continue
+		}
+		monster := raw.(*gen.MappedMultilangMonster)
+
+		source := APIDropSource{
+			AnkamaId:  monster.AnkamaId,
+			Name:      monster.Name[lang],
+			Level:     MonsterLevelRange(monster),
+			ImageUrls: ApiImageUrls{Icon: monster.Image},
+		}
+		for _, drop := range monster.Drops {
+			if drop.ItemId == item.AnkamaId {
+				source.PercentagesByGrade = drop.Percentages
+				break
+			}
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+type APIListMonster struct {
+	Id        int          `json:"ankama_id"`
+	Name      string       `json:"name"`
+	Race      ApiType      `json:"race"`
+	Level     APIRange     `json:"level"`
+	IsBoss    bool         `json:"is_boss"`
+	ImageUrls ApiImageUrls `json:"image_urls,omitempty"`
+
+	// extra fields
+	Grades []APIMonsterGrade `json:"grades,omitempty"`
+	Drops  []APIMonsterDrop  `json:"drops,omitempty"`
+}
+
+func RenderMonsterListEntry(monster *gen.MappedMultilangMonster, lang string) APIListMonster {
+	return APIListMonster{
+		Id:   monster.AnkamaId,
+		Name: monster.Name[lang],
+		Race: ApiType{
+			Name: monster.Race.Name[lang],
+			Id:   monster.Race.Id,
+		},
+		Level:     MonsterLevelRange(monster),
+		IsBoss:    monster.IsBoss,
+		ImageUrls: ApiImageUrls{Icon: monster.Image},
+	}
+}
+
+type APIMonster struct {
+	Id             int               `json:"ankama_id"`
+	Name           string            `json:"name"`
+	Race           ApiType           `json:"race"`
+	Level          APIRange          `json:"level"`
+	IsBoss         bool              `json:"is_boss"`
+	IsMiniBoss     bool              `json:"is_mini_boss"`
+	IsQuestMonster bool              `json:"is_quest_monster"`
+	ImageUrls      ApiImageUrls      `json:"image_urls,omitempty"`
+	Grades         []APIMonsterGrade `json:"grades"`
+	Drops          []APIMonsterDrop  `json:"drops,omitempty"`
+}
+
+func RenderMonster(monster *gen.MappedMultilangMonster, lang string) APIMonster {
+	return APIMonster{
+		Id:   monster.AnkamaId,
+		Name: monster.Name[lang],
+		Race: ApiType{
+			Name: monster.Race.Name[lang],
+			Id:   monster.Race.Id,
+		},
+		Level:          MonsterLevelRange(monster),
+		IsBoss:         monster.IsBoss,
+		IsMiniBoss:     monster.IsMiniBoss,
+		IsQuestMonster: monster.IsQuestMonster,
+		ImageUrls:      ApiImageUrls{Icon: monster.Image},
+		Grades:         RenderMonsterGrades(monster),
+		Drops:          RenderMonsterDrops(monster, lang, Db),
+	}
+}
+
+type APIAlmanaxBonus struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type APIAlmanaxTribute struct {
+	Item     APIListTypedItem `json:"item"`
+	Quantity int              `json:"quantity"`
+}
+
+type APIAlmanax struct {
+	Date    string             `json:"date"`
+	Bonus   APIAlmanaxBonus    `json:"bonus"`
+	Npc     ApiType            `json:"npc"`
+	Tribute *APIAlmanaxTribute `json:"tribute,omitempty"`
+}
+
+func RenderAlmanax(day *gen.MappedMultilangAlmanax, date string, lang string, txn *memdb.Txn) APIAlmanax {
+	almanax := APIAlmanax{
+		Date: date,
+		Bonus: APIAlmanaxBonus{
+			Name:        day.Bonus[lang],
+			Description: day.BonusDescription[lang],
+		},
+		Npc: ApiType{
+			Name: day.NpcName[lang],
+			Id:   day.NpcId,
+		},
+	}
+
+	if day.HasOffering {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", day.Offering.ItemId)
+		if err != nil {
+			log.Println(err)
+			return almanax
+		}
+		if raw != nil {
+			almanax.Tribute = &APIAlmanaxTribute{
+				Item:     RenderTypedItemListEntry(raw.(*gen.MappedMultilangItem), lang),
+				Quantity: day.Offering.Quantity,
+			}
+		}
+	}
+
+	return almanax
+}
+
+type APIPageIdol struct {
+	Links utils.PaginationLinks `json:"_links,omitempty"`
+	Items []APIListIdol         `json:"idols"`
+}
+
+type APIIdolSpell struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IconId      int    `json:"icon_id"`
+}
+
+type APIIdolSynergy struct {
+	Id          int     `json:"ankama_id"`
+	Name        string  `json:"name"`
+	Coefficient float64 `json:"coefficient"`
+}
+
+type APIListIdol struct {
+	Id        int          `json:"ankama_id"`
+	Name      string       `json:"name"`
+	Level     int          `json:"level"`
+	Score     int          `json:"score"`
+	GroupOnly bool         `json:"group_only"`
+	ImageUrls ApiImageUrls `json:"image_urls,omitempty"`
+
+	// extra fields
+	Spell     *APIIdolSpell    `json:"spell,omitempty"`
+	Synergies []APIIdolSynergy `json:"synergies,omitempty"`
+}
+
+func RenderIdolListEntry(idol *gen.MappedMultilangIdol, lang string) APIListIdol {
+	return APIListIdol{
+		Id:        idol.AnkamaId,
+		Name:      idol.Name[lang],
+		Level:     idol.Level,
+		Score:     idol.Score,
+		GroupOnly: idol.GroupOnly,
+		ImageUrls: RenderImageUrls(utils.ImageUrls(idol.IconId, "item")),
+	}
+}
+
+func RenderIdolSpell(idol *gen.MappedMultilangIdol, lang string) APIIdolSpell {
+	return APIIdolSpell{
+		Id:          idol.Spell.Id,
+		Name:        idol.Spell.Name[lang],
+		Description: idol.Spell.Description[lang],
+		IconId:      idol.Spell.IconId,
+	}
+}
+
+func RenderIdolSynergies(idol *gen.MappedMultilangIdol, lang string, db *memdb.MemDB) []APIIdolSynergy {
+	if len(idol.Synergies) == 0 {
+		return nil
+	}
+
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	var synergies []APIIdolSynergy
+	for _, synergy := range idol.Synergies {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "idols"), "id", synergy.IdolId)
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+		if raw == nil {
+			continue
+		}
+		synergies = append(synergies, APIIdolSynergy{
+			Id:          synergy.IdolId,
+			Name:        raw.(*gen.MappedMultilangIdol).Name[lang],
+			Coefficient: synergy.Coefficient,
+		})
+	}
+	return synergies
+}
+
+type APIIdol struct {
+	Id              int              `json:"ankama_id"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	ItemId          int              `json:"item_ankama_id"`
+	CategoryId      int              `json:"category_id"`
+	Level           int              `json:"level"`
+	Score           int              `json:"score"`
+	ExperienceBonus int              `json:"experience_bonus"`
+	DropBonus       int              `json:"drop_bonus"`
+	GroupOnly       bool             `json:"group_only"`
+	ImageUrls       ApiImageUrls     `json:"image_urls,omitempty"`
+	Spell           APIIdolSpell     `json:"spell"`
+	Synergies       []APIIdolSynergy `json:"synergies,omitempty"`
+}
+
+func RenderIdol(idol *gen.MappedMultilangIdol, lang string) APIIdol {
+	return APIIdol{
+		Id:              idol.AnkamaId,
+		Name:            idol.Name[lang],
+		Description:     idol.Description[lang],
+		ItemId:          idol.ItemId,
+		CategoryId:      idol.CategoryId,
+		Level:           idol.Level,
+		Score:           idol.Score,
+		ExperienceBonus: idol.ExperienceBonus,
+		DropBonus:       idol.DropBonus,
+		GroupOnly:       idol.GroupOnly,
+		ImageUrls:       RenderImageUrls(utils.ImageUrls(idol.IconId, "item")),
+		Spell:           RenderIdolSpell(idol, lang),
+		Synergies:       RenderIdolSynergies(idol, lang, Db),
+	}
+}
+
+type APIPageCompanion struct {
+	Links utils.PaginationLinks `json:"_links,omitempty"`
+	Items []APIListCompanion    `json:"companions"`
+}
+
+type APICompanionSpell struct {
+	Spell        APIListSpell `json:"spell"`
+	GradeByLevel string       `json:"grade_by_level"`
+}
+
+type APICompanionStatStep struct {
+	Level float64 `json:"level"`
+	Value float64 `json:"value"`
+}
+
+type APICompanionCharacteristic struct {
+	Id      int                    `json:"id"`
+	Name    string                 `json:"name"`
+	Scaling []APICompanionStatStep `json:"scaling"`
+}
+
+type APIListCompanion struct {
+	Id   int    `json:"ankama_id"`
+	Name string `json:"name"`
+
+	// extra fields
+	Spells          []APICompanionSpell          `json:"spells,omitempty"`
+	Characteristics []APICompanionCharacteristic `json:"characteristics,omitempty"`
+}
+
+func RenderCompanionListEntry(companion *gen.MappedMultilangCompanion, lang string) APIListCompanion {
+	return APIListCompanion{
+		Id:   companion.AnkamaId,
+		Name: companion.Name[lang],
+	}
+}
+
+func RenderCompanionSpells(companion *gen.MappedMultilangCompanion, lang string, db *memdb.MemDB) []APICompanionSpell {
+	if len(companion.Spells) == 0 {
+		return nil
+	}
+
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	var spells []APICompanionSpell
+	for _, companionSpell := range companion.Spells {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "spells"), "id", companionSpell.SpellId)
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+		if raw == nil {
+			continue
+		}
+		spells = append(spells, APICompanionSpell{
+			Spell:        RenderSpellListEntry(raw.(*gen.MappedMultilangSpell), lang),
+			GradeByLevel: companionSpell.GradeByLevel,
+		})
+	}
+	return spells
+}
+
+func RenderCompanionCharacteristics(companion *gen.MappedMultilangCompanion, lang string) []APICompanionCharacteristic {
+	if len(companion.Characteristics) == 0 {
+		return nil
+	}
+
+	var characteristics []APICompanionCharacteristic
+	for _, characteristic := range companion.Characteristics {
+		var scaling []APICompanionStatStep
+		for _, step := range characteristic.Scaling {
+			scaling = append(scaling, APICompanionStatStep{
+				Level: step.Level,
+				Value: step.Value,
+			})
+		}
+		characteristics = append(characteristics, APICompanionCharacteristic{
+			Id:      characteristic.Id,
+			Name:    characteristic.Name[lang],
+			Scaling: scaling,
+		})
+	}
+	return characteristics
+}
+
+type APICompanion struct {
+	Id              int                          `json:"ankama_id"`
+	Name            string                       `json:"name"`
+	Description     string                       `json:"description"`
+	UnlockItem      *APIListTypedItem            `json:"unlock_item,omitempty"`
+	Spells          []APICompanionSpell          `json:"spells,omitempty"`
+	Characteristics []APICompanionCharacteristic `json:"characteristics,omitempty"`
+}
+
+func RenderCompanion(companion *gen.MappedMultilangCompanion, lang string, db *memdb.MemDB) APICompanion {
+	resCompanion := APICompanion{
+		Id:              companion.AnkamaId,
+		Name:            companion.Name[lang],
+		Description:     companion.Description[lang],
+		Spells:          RenderCompanionSpells(companion, lang, db),
+		Characteristics: RenderCompanionCharacteristics(companion, lang),
+	}
+
+	if companion.HasUnlockItem {
+		txn := db.Txn(false)
+		defer txn.Abort()
+
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", companion.UnlockItemId)
+		if err != nil {
+			log.Println(err)
+			return resCompanion
+		}
+		if raw != nil {
+			unlockItem := RenderTypedItemListEntry(raw.(*gen.MappedMultilangItem), lang)
+			resCompanion.UnlockItem = &unlockItem
+		}
+	}
+
+	return resCompanion
+}
+
+type APIAreaBounds struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type APIArea struct {
+	Id              int           `json:"ankama_id"`
+	Name            string        `json:"name"`
+	SuperAreaId     int           `json:"super_area_ankama_id"`
+	ContainHouses   bool          `json:"contain_houses"`
+	ContainPaddocks bool          `json:"contain_paddocks"`
+	Bounds          APIAreaBounds `json:"bounds"`
+	WorldmapId      int           `json:"worldmap_id"`
+	HasWorldMap     bool          `json:"has_world_map"`
+}
+
+func RenderArea(area *gen.MappedMultilangArea, lang string) APIArea {
+	return APIArea{
+		Id:              area.AnkamaId,
+		Name:            area.Name[lang],
+		SuperAreaId:     area.SuperAreaId,
+		ContainHouses:   area.ContainHouses,
+		ContainPaddocks: area.ContainPaddocks,
+		Bounds: APIAreaBounds{
+			X:      area.Bounds.X,
+			Y:      area.Bounds.Y,
+			Width:  area.Bounds.Width,
+			Height: area.Bounds.Height,
+		},
+		WorldmapId:  area.WorldmapId,
+		HasWorldMap: area.HasWorldMap,
+	}
+}
+
+type APIListSuperArea struct {
+	Id          int    `json:"ankama_id"`
+	Name        string `json:"name"`
+	WorldmapId  int    `json:"worldmap_id"`
+	HasWorldMap bool   `json:"has_world_map"`
+}
+
+func RenderSuperAreaListEntry(superArea *gen.MappedMultilangSuperArea, lang string) APIListSuperArea {
+	return APIListSuperArea{
+		Id:          superArea.AnkamaId,
+		Name:        superArea.Name[lang],
+		WorldmapId:  superArea.WorldmapId,
+		HasWorldMap: superArea.HasWorldMap,
+	}
+}
+
+type APISuperArea struct {
+	Id          int       `json:"ankama_id"`
+	Name        string    `json:"name"`
+	WorldmapId  int       `json:"worldmap_id"`
+	HasWorldMap bool      `json:"has_world_map"`
+	Areas       []APIArea `json:"areas"`
+}
+
+func RenderSuperArea(superArea *gen.MappedMultilangSuperArea, lang string, db *memdb.MemDB) APISuperArea {
+	resSuperArea := APISuperArea{
+		Id:          superArea.AnkamaId,
+		Name:        superArea.Name[lang],
+		WorldmapId:  superArea.WorldmapId,
+		HasWorldMap: superArea.HasWorldMap,
+		Areas:       []APIArea{},
+	}
+
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	for _, areaId := range superArea.AreaIds {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "areas"), "id", areaId)
+		if err != nil {
+			log.Println(err)
+			return resSuperArea
+		}
+		if raw == nil {
+			continue
+		}
+		resSuperArea.Areas = append(resSuperArea.Areas, RenderArea(raw.(*gen.MappedMultilangArea), lang))
+	}
+
+	return resSuperArea
+}
+
+type APIPageNpc struct {
+	Links utils.PaginationLinks `json:"_links,omitempty"`
+	Items []APIListNpc          `json:"npcs"`
+}
+
+type APINpcDialogEntry struct {
+	Id   int    `json:"id"`
+	Text string `json:"text"`
+}
+
+type APINpcDialogMessage struct {
+	Id      int                 `json:"id"`
+	Text    string              `json:"text"`
+	Replies []APINpcDialogEntry `json:"replies"`
+}
+
+type APINpcDialog struct {
+	Messages        []APINpcDialogMessage `json:"messages"`
+	UnlinkedReplies []APINpcDialogEntry   `json:"unlinked_replies"`
+}
+
+type APIListNpc struct {
+	Id   int    `json:"ankama_id"`
+	Name string `json:"name"`
+
+	// extra fields
+	Dialog *APINpcDialog `json:"dialog,omitempty"`
+}
+
+func RenderNpcListEntry(npc *gen.MappedMultilangNpc, lang string) APIListNpc {
+	return APIListNpc{
+		Id:   npc.AnkamaId,
+		Name: npc.Name[lang],
+	}
+}
+
+func renderNpcDialogEntries(entries []gen.MappedMultilangNpcDialogEntry, lang string) []APINpcDialogEntry {
+	res := []APINpcDialogEntry{}
+	for _, entry := range entries {
+		res = append(res, APINpcDialogEntry{
+			Id:   entry.Id,
+			Text: entry.Text[lang],
+		})
+	}
+	return res
+}
+
+func RenderNpcDialog(npc *gen.MappedMultilangNpc, lang string) APINpcDialog {
+	dialog := APINpcDialog{
+		Messages:        []APINpcDialogMessage{},
+		UnlinkedReplies: renderNpcDialogEntries(npc.UnlinkedReplies, lang),
+	}
+	for _, message := range npc.Messages {
+		dialog.Messages = append(dialog.Messages, APINpcDialogMessage{
+			Id:      message.Id,
+			Text:    message.Text[lang],
+			Replies: renderNpcDialogEntries(message.Replies, lang),
+		})
+	}
+	return dialog
+}
+
+type APINpc struct {
+	Id      int          `json:"ankama_id"`
+	Name    string       `json:"name"`
+	Dialog  APINpcDialog `json:"dialog"`
+	Actions []int        `json:"action_ids"`
+}
+
+func RenderNpc(npc *gen.MappedMultilangNpc, lang string) APINpc {
+	return APINpc{
+		Id:      npc.AnkamaId,
+		Name:    npc.Name[lang],
+		Dialog:  RenderNpcDialog(npc, lang),
+		Actions: npc.Actions,
+	}
+}
+
+type APIJobSkill struct {
+	Id                     int    `json:"id"`
+	Name                   string `json:"name"`
+	LevelMin               int    `json:"level_min"`
+	IsForgemagus           bool   `json:"is_forgemagus"`
+	GatheredResourceItemId int    `json:"gathered_resource_item_ankama_id,omitempty"`
+}
+
+type APIListJob struct {
+	Id     int    `json:"ankama_id"`
+	Name   string `json:"name"`
+	IconId int    `json:"icon_id"`
+}
+
+func RenderJobListEntry(job *gen.MappedMultilangJob, lang string) APIListJob {
+	return APIListJob{
+		Id:     job.AnkamaId,
+		Name:   job.Name[lang],
+		IconId: job.IconId,
+	}
+}
+
+type APIJob struct {
+	Id     int           `json:"ankama_id"`
+	Name   string        `json:"name"`
+	IconId int           `json:"icon_id"`
+	Skills []APIJobSkill `json:"skills"`
+}
+
+func RenderJob(job *gen.MappedMultilangJob, lang string) APIJob {
+	resJob := APIJob{
+		Id:     job.AnkamaId,
+		Name:   job.Name[lang],
+		IconId: job.IconId,
+		Skills: []APIJobSkill{},
+	}
+
+	for _, skill := range job.Skills {
+		resJob.Skills = append(resJob.Skills, APIJobSkill{
+			Id:                     skill.Id,
+			Name:                   skill.Name[lang],
+			LevelMin:               skill.LevelMin,
+			IsForgemagus:           skill.IsForgemagus,
+			GatheredResourceItemId: skill.GatheredResourceItemId,
+		})
+	}
+
+	return resJob
+}
+
+type APIPageJobRecipe struct {
+	Links utils.PaginationLinks `json:"_links,omitempty"`
+	Items []APIJobRecipe        `json:"recipes"`
+}
+
+type APIJobRecipe struct {
+	Result      APIListTypedItem `json:"result"`
+	Level       int              `json:"level"`
+	SkillId     int              `json:"skill_id"`
+	Ingredients []APIRecipe      `json:"ingredients"`
+}
+
+func RenderJobRecipe(recipe *gen.MappedMultilangRecipe, result *gen.MappedMultilangItem, lang string) APIJobRecipe {
+	return APIJobRecipe{
+		Result:      RenderTypedItemListEntry(result, lang),
+		Level:       recipe.Level,
+		SkillId:     recipe.SkillId,
+		Ingredients: RenderRecipe(*recipe, Db),
+	}
+}
+
+type APIRecipeTreeNode struct {
+	Item        APIListTypedItem    `json:"item"`
+	Quantity    int                 `json:"quantity"`
+	Cycle       bool                `json:"cycle,omitempty"`
+	Ingredients []APIRecipeTreeNode `json:"ingredients,omitempty"`
+}
+
+type APIRecipeTreeResource struct {
+	Item     APIListTypedItem `json:"item"`
+	Quantity int              `json:"quantity"`
+}
+
+type APIRecipeTree struct {
+	Quantity      int                     `json:"quantity"`
+	Tree          APIRecipeTreeNode       `json:"tree"`
+	BaseResources []APIRecipeTreeResource `json:"base_resources"`
+}
+
+// renderRecipeTreeNode expands the recipe of an item recursively. Items already on the current path are
+// marked as cycle and, like uncraftable items, counted as base resources.
+func renderRecipeTreeNode(item *gen.MappedMultilangItem, quantity int, lang string, txn *memdb.Txn, path map[int]bool, baseResources map[int]int) APIRecipeTreeNode {
+	node := APIRecipeTreeNode{
+		Item:     RenderTypedItemListEntry(item, lang),
+		Quantity: quantity,
+	}
+
+	if path[item.AnkamaId] {
+		node.Cycle = true
+		baseResources[item.AnkamaId] += quantity
+		return node
+	}
+
+	recipe, exists := GetRecipeIfExists(item.AnkamaId, txn)
+	if !exists || len(recipe.Entries) == 0 {
+		baseResources[item.AnkamaId] += quantity
+		return node
+	}
+
+	path[item.AnkamaId] = true
+	for _, entry := range recipe.Entries {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", entry.ItemId)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if raw == nil {
+			continue
+		}
+		node.Ingredients = append(node.Ingredients, renderRecipeTreeNode(raw.(*gen.MappedMultilangItem), entry.Quantity*quantity, lang, txn, path, baseResources))
+	}
+	delete(path, item.AnkamaId)
+
+	return node
+}
+
+func RenderRecipeTree(item *gen.MappedMultilangItem, quantity int, lang string, txn *memdb.Txn) APIRecipeTree {
+	baseResources := make(map[int]int)
+	tree := APIRecipeTree{
+		Quantity:      quantity,
+		Tree:          renderRecipeTreeNode(item, quantity, lang, txn, make(map[int]bool), baseResources),
+		BaseResources: []APIRecipeTreeResource{},
+	}
+
+	for itemId, itemQuantity := range baseResources {
+		raw, err := txn.First(fmt.Sprintf("%s-%s", utils.CurrentRedBlueVersionStr(Version.MemDb), "all_items"), "id", itemId)
+		if err != nil || raw == nil {
+			continue
+		}
+		tree.BaseResources = append(tree.BaseResources, APIRecipeTreeResource{
+			Item:     RenderTypedItemListEntry(raw.(*gen.MappedMultilangItem), lang),
+			Quantity: itemQuantity,
+		})
+	}
+	sort.Slice(tree.BaseResources, func(i, j int) bool {
+		return tree.BaseResources[i].Item.Id < tree.BaseResources[j].Item.Id
+	})
+
+	return tree
+}
@@ -10,11 +10,14 @@ func DownloadItems(hashJson *ankabuffer.Manifest) error {
 		{Filename: "data/common/Effects.d2o", FriendlyName: "data/tmp/effects.d2o"},
 		{Filename: "data/common/Bonuses.d2o", FriendlyName: "data/tmp/bonuses.d2o"},
 		{Filename: "data/common/Recipes.d2o", FriendlyName: "data/tmp/recipes.d2o"},
+		{Filename: "data/common/Jobs.d2o", FriendlyName: "data/tmp/jobs.d2o"},
+		{Filename: "data/common/Skills.d2o", FriendlyName: "data/tmp/skills.d2o"},
 		{Filename: "data/common/Spells.d2o", FriendlyName: "data/tmp/spells.d2o"},
 		{Filename: "data/common/SpellTypes.d2o", FriendlyName: "data/tmp/spell_types.d2o"},
 		{Filename: "data/common/Breeds.d2o", FriendlyName: "data/tmp/breeds.d2o"},
 		{Filename: "data/common/Mounts.d2o", FriendlyName: "data/tmp/mounts.d2o"},
 		{Filename: "data/common/Idols.d2o", FriendlyName: "data/tmp/idols.d2o"},
+		{Filename: "data/common/SpellPairs.d2o", FriendlyName: "data/tmp/spell_pairs.d2o"},
 		{Filename: "data/common/AlmanaxCalendars.d2o", FriendlyName: "data/tmp/almanax.d2o"},
 		{Filename: "data/common/MonsterRaces.d2o", FriendlyName: "data/tmp/monster_races.d2o"},
 		{Filename: "data/common/Monsters.d2o", FriendlyName: "data/tmp/monsters.d2o"},
@@ -22,14 +25,24 @@ func DownloadItems(hashJson *ankabuffer.Manifest) error {
 		{Filename: "data/common/CompanionSpells.d2o", FriendlyName: "data/tmp/companion_spells.d2o"},
 		{Filename: "data/common/Companions.d2o", FriendlyName: "data/tmp/companions.d2o"},
 		{Filename: "data/common/Areas.d2o", FriendlyName: "data/tmp/areas.d2o"},
+		{Filename: "data/common/SuperAreas.d2o", FriendlyName: "data/tmp/super_areas.d2o"},
 		{Filename: "data/common/MountFamily.d2o", FriendlyName: "data/tmp/mount_family.d2o"},
 		{Filename: "data/common/Npcs.d2o", FriendlyName: "data/tmp/npcs.d2o"},
+		{Filename: "data/common/NpcMessages.d2o", FriendlyName: "data/tmp/npc_messages.d2o"},
 		{Filename: "data/common/ServerGameTypes.d2o", FriendlyName: "data/tmp/server_game_types.d2o"},
 		{Filename: "data/common/CharacteristicCategories.d2o", FriendlyName: "data/tmp/chars_categories.d2o"},
+		{Filename: "data/common/Characteristics.d2o", FriendlyName: "data/tmp/characteristics.d2o"},
 		{Filename: "data/common/CreatureBonesTypes.d2o", FriendlyName: "data/tmp/creature_bone_types.d2o"},
 		{Filename: "data/common/CreatureBonesOverrides.d2o", FriendlyName: "data/tmp/create_bone_overrides.d2o"},
 		{Filename: "data/common/EvolutiveEffects.d2o", FriendlyName: "data/tmp/evol_effects.d2o"},
 		{Filename: "data/common/BonusesCriterions.d2o", FriendlyName: "data/tmp/bonus_criterions.d2o"},
+		{Filename: "data/common/QuestObjectives.d2o", FriendlyName: "data/tmp/quest_objectives.d2o"},
+		{Filename: "data/common/Quests.d2o", FriendlyName: "data/tmp/quests.d2o"},
+		{Filename: "data/common/QuestCategory.d2o", FriendlyName: "data/tmp/quest_categories.d2o"},
+		{Filename: "data/common/Emoticons.d2o", FriendlyName: "data/tmp/emoticons.d2o"},
+		{Filename: "data/common/Achievements.d2o", FriendlyName: "data/tmp/achievements.d2o"},
+		{Filename: "data/common/SubAreas.d2o", FriendlyName: "data/tmp/sub_areas.d2o"},
+		{Filename: "data/common/AlignmentSides.d2o", FriendlyName: "data/tmp/alignment_sides.d2o"},
 	}
 
 	return DownloadUnpackFiles(hashJson, "main", fileNames, "data", true)
@@ -135,11 +135,35 @@ func CleanUp() {
 		"data/breeds.json",
 		"data/creature_bone_types.json",
 		"data/monster_races.json",
+		"data/quest_objectives.json",
+		"data/spell_pairs.json",
+		"data/characteristics.json",
+		"data/super_areas.json",
+		"data/npc_messages.json",
+		"data/jobs.json",
+		"data/skills.json",
+		"data/quests.json",
+		"data/quest_categories.json",
+		"data/emoticons.json",
+		"data/achievements.json",
+		"data/sub_areas.json",
+		"data/alignment_sides.json",
 
 		"data/MAPPED_ITEMS.json",
+		"data/UNHANDLED_CRITERIA.json",
 		"data/MAPPED_SETS.json",
 		"data/MAPPED_RECIPES.json",
 		"data/MAPPED_MOUNTS.json",
+		"data/MAPPED_SPELLS.json",
+		"data/MAPPED_BREEDS.json",
+		"data/MAPPED_MONSTERS.json",
+		"data/MAPPED_ALMANAX.json",
+		"data/MAPPED_IDOLS.json",
+		"data/MAPPED_COMPANIONS.json",
+		"data/MAPPED_AREAS.json",
+		"data/MAPPED_SUPER_AREAS.json",
+		"data/MAPPED_NPCS.json",
+		"data/MAPPED_JOBS.json",
 	}
 	for _, lang := range utils.Languages {
 		langJson := fmt.Sprintf("data/languages/lang_%s.json", lang)
@@ -154,28 +178,12 @@ func CleanUp() {
 	//os.RemoveAll("data/img") // keep old images, override with new ones, else they are unavailable while updating
 	//os.Mkdir("data/img", 0755)
 
-	meiliClient := utils.CreateMeiliClient()
-	if meiliClient == nil {
-		log.Fatal("meili could not be reached")
-	}
-
 	for _, lang := range utils.Languages {
-		taskItemsDelete, err := meiliClient.DeleteIndex(fmt.Sprintf("all_items-%s", lang))
-		if err != nil {
-			log.Println(err)
-		}
-		taskSetsDelete, err := meiliClient.DeleteIndex(fmt.Sprintf("sets-%s", lang))
-		if err != nil {
-			log.Println(err)
-		}
-		taskMountsDelete, err := meiliClient.DeleteIndex(fmt.Sprintf("mounts-%s", lang))
-		if err != nil {
-			log.Println(err)
+		for _, legacyIndex := range []string{"all_items", "sets", "mounts", "spells", "monsters", "companions"} {
+			if err := utils.Search.DeleteIndex(fmt.Sprintf("%s-%s", legacyIndex, lang)); err != nil {
+				log.Println(err)
+			}
 		}
-
-		_, _ = meiliClient.WaitForTask(taskItemsDelete.TaskUID)
-		_, _ = meiliClient.WaitForTask(taskSetsDelete.TaskUID)
-		_, _ = meiliClient.WaitForTask(taskMountsDelete.TaskUID)
 	}
 
 }
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+var collationFolds = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a",
+	'æ': "ae", 'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o",
+	'œ': "oe", 'ß': "ss",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u",
+	'ý': "y", 'ÿ': "y",
+}
+
+// CollationKey folds case and the accents used by the supported languages, so "Épée" sorts next to "epee".
+func CollationKey(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range strings.ToLower(s) {
+		if folded, ok := collationFolds[r]; ok {
+			b.WriteString(folded)
+			continue
+		}
+		if unicode.IsPunct(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// CompareCollated orders two strings by their collation key and falls back to the raw strings for a stable order.
+func CompareCollated(a string, b string) int {
+	if c := strings.Compare(CollationKey(a), CollationKey(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
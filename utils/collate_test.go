@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollationKeyAccents(t *testing.T) {
+	assert.Equal(t, "epee", CollationKey("Épée"))
+	assert.Equal(t, "strasse", CollationKey("Straße"))
+	assert.Equal(t, "oeil", CollationKey("Œil"))
+}
+
+func TestCompareCollated(t *testing.T) {
+	assert.Equal(t, -1, CompareCollated("Écharpe", "Ficelle"))
+	assert.Equal(t, 1, CompareCollated("zèbre", "Zebre"))
+	assert.Equal(t, 0, CompareCollated("Coiffe", "Coiffe"))
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	FilterGte = "gte"
+	FilterLte = "lte"
+	FilterEq  = "eq"
+	FilterNe  = "ne"
+	FilterIn  = "in"
+)
+
+// FilterField describes a field that can be used with filter[field][operator]=value.
+type FilterField struct {
+	IsBool    bool
+	MeiliName string // attribute in the search index, empty if the field is not searchable
+}
+
+// FieldFilter is a parsed filter[field][operator]=value parameter. Booleans are stored as 0 and 1.
+type FieldFilter struct {
+	Field    string
+	Operator string
+	Values   []int
+}
+
+var fieldFilterRegex = regexp.MustCompile(`^filter\[([a-z_]+)\]\[([a-z]+)\]$`)
+
+func parseFilterValue(value string, isBool bool) (int, error) {
+	if isBool {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return 0, err
+		}
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// ParseFieldFilters collects all filter[field][operator] parameters. Unknown fields or operators are an error
+// so typos do not silently return unfiltered lists.
+func ParseFieldFilters(query url.Values, fields map[string]FilterField) ([]FieldFilter, error) {
+	var filters []FieldFilter
+	for key, values := range query {
+		match := fieldFilterRegex.FindStringSubmatch(strings.ToLower(key))
+		if match == nil {
+			continue
+		}
+		fieldName, operator := match[1], match[2]
+		field, ok := fields[fieldName]
+		if !ok {
+			return nil, fmt.Errorf("filter[%s] unknown", fieldName)
+		}
+
+		switch operator {
+		case FilterGte, FilterLte:
+			if field.IsBool {
+				return nil, fmt.Errorf("filter[%s][%s] not supported", fieldName, operator)
+			}
+		case FilterEq, FilterNe, FilterIn:
+		default:
+			return nil, fmt.Errorf("filter[%s][%s] unknown operator", fieldName, operator)
+		}
+
+		for _, value := range values {
+			filter := FieldFilter{Field: fieldName, Operator: operator}
+			rawValues := []string{value}
+			if operator == FilterIn {
+				rawValues = strings.Split(value, ",")
+			}
+			for _, rawValue := range rawValues {
+				parsed, err := parseFilterValue(strings.TrimSpace(rawValue), field.IsBool)
+				if err != nil {
+					return nil, fmt.Errorf("filter[%s][%s]", fieldName, operator)
+				}
+				filter.Values = append(filter.Values, parsed)
+			}
+			filters = append(filters, filter)
+		}
+	}
+
+	sort.Slice(filters, func(i, j int) bool { // deterministic order for the search filter string
+		if filters[i].Field != filters[j].Field {
+			return filters[i].Field < filters[j].Field
+		}
+		return filters[i].Operator < filters[j].Operator
+	})
+
+	return filters, nil
+}
+
+func (f *FieldFilter) Matches(value int) bool {
+	switch f.Operator {
+	case FilterGte:
+		return value >= f.Values[0]
+	case FilterLte:
+		return value <= f.Values[0]
+	case FilterEq:
+		return value == f.Values[0]
+	case FilterNe:
+		return value != f.Values[0]
+	case FilterIn:
+		for _, v := range f.Values {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchesFieldFilters checks every filter against the value the entity has for the field.
+func MatchesFieldFilters(filters []FieldFilter, value func(field string) int) bool {
+	for i := range filters {
+		if !filters[i].Matches(value(filters[i].Field)) {
+			return false
+		}
+	}
+	return true
+}
+
+func meiliFilterValue(value int, field FilterField) string {
+	if field.IsBool {
+		return strconv.FormatBool(value != 0)
+	}
+	return strconv.Itoa(value)
+}
+
+// MeiliFilterFromFieldFilters translates the filters to the Meilisearch filter syntax and joins them with AND.
+func MeiliFilterFromFieldFilters(filters []FieldFilter, fields map[string]FilterField) (string, error) {
+	var parts []string
+	for _, filter := range filters {
+		field := fields[filter.Field]
+		if field.MeiliName == "" {
+			return "", fmt.Errorf("filter[%s] not searchable", filter.Field)
+		}
+
+		switch filter.Operator {
+		case FilterGte:
+			parts = append(parts, fmt.Sprintf("%s >= %s", field.MeiliName, meiliFilterValue(filter.Values[0], field)))
+		case FilterLte:
+			parts = append(parts, fmt.Sprintf("%s <= %s", field.MeiliName, meiliFilterValue(filter.Values[0], field)))
+		case FilterEq:
+			parts = append(parts, fmt.Sprintf("%s = %s", field.MeiliName, meiliFilterValue(filter.Values[0], field)))
+		case FilterNe:
+			parts = append(parts, fmt.Sprintf("%s != %s", field.MeiliName, meiliFilterValue(filter.Values[0], field)))
+		case FilterIn:
+			var values []string
+			for _, value := range filter.Values {
+				values = append(values, meiliFilterValue(value, field))
+			}
+			parts = append(parts, fmt.Sprintf("%s IN [%s]", field.MeiliName, strings.Join(values, ", ")))
+		}
+	}
+	return strings.Join(parts, " AND "), nil
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFilterFields = map[string]FilterField{
+	"level":          {MeiliName: "level"},
+	"has_parent_set": {IsBool: true, MeiliName: "has_parent_set"},
+	"item_count":     {},
+}
+
+func TestParseFieldFilters(t *testing.T) {
+	tests := []struct {
+		query   string
+		filters []FieldFilter
+		err     bool
+	}{
+		{query: "", filters: nil},
+		{query: "filter[level][gte]=10", filters: []FieldFilter{{Field: "level", Operator: FilterGte, Values: []int{10}}}},
+		{query: "filter[level][lte]=50", filters: []FieldFilter{{Field: "level", Operator: FilterLte, Values: []int{50}}}},
+		{query: "filter[level][eq]=20", filters: []FieldFilter{{Field: "level", Operator: FilterEq, Values: []int{20}}}},
+		{query: "filter[level][ne]=20", filters: []FieldFilter{{Field: "level", Operator: FilterNe, Values: []int{20}}}},
+		{query: "filter[level][in]=1, 2,3", filters: []FieldFilter{{Field: "level", Operator: FilterIn, Values: []int{1, 2, 3}}}},
+		{query: "filter[has_parent_set][eq]=true", filters: []FieldFilter{{Field: "has_parent_set", Operator: FilterEq, Values: []int{1}}}},
+		{
+			query: "filter[level][lte]=50&filter[has_parent_set][ne]=false&filter[level][gte]=10&filter[min_level]=3",
+			filters: []FieldFilter{
+				{Field: "has_parent_set", Operator: FilterNe, Values: []int{0}},
+				{Field: "level", Operator: FilterGte, Values: []int{10}},
+				{Field: "level", Operator: FilterLte, Values: []int{50}},
+			},
+		},
+		{query: "filter[pods][gte]=10", err: true},
+		{query: "filter[level][between]=10", err: true},
+		{query: "filter[level][gte]=ten", err: true},
+		{query: "filter[level][in]=1,,2", err: true},
+		{query: "filter[has_parent_set][gte]=true", err: true},
+		{query: "filter[has_parent_set][eq]=maybe", err: true},
+	}
+
+	for _, test := range tests {
+		query, err := url.ParseQuery(test.query)
+		assert.Nil(t, err)
+		filters, err := ParseFieldFilters(query, testFilterFields)
+		if test.err {
+			assert.NotNil(t, err, test.query)
+			continue
+		}
+		assert.Nil(t, err, test.query)
+		assert.Equal(t, test.filters, filters, test.query)
+	}
+}
+
+func TestMatchesFieldFilters(t *testing.T) {
+	filters := []FieldFilter{
+		{Field: "level", Operator: FilterIn, Values: []int{10, 20}},
+		{Field: "has_parent_set", Operator: FilterEq, Values: []int{1}},
+	}
+	values := map[string]int{"level": 20, "has_parent_set": 1}
+	value := func(field string) int { return values[field] }
+
+	assert.True(t, MatchesFieldFilters(filters, value))
+	values["level"] = 30
+	assert.False(t, MatchesFieldFilters(filters, value))
+}
+
+func TestMeiliFilterFromFieldFilters(t *testing.T) {
+	filter, err := MeiliFilterFromFieldFilters([]FieldFilter{
+		{Field: "has_parent_set", Operator: FilterEq, Values: []int{0}},
+		{Field: "level", Operator: FilterGte, Values: []int{10}},
+		{Field: "level", Operator: FilterIn, Values: []int{1, 2}},
+		{Field: "level", Operator: FilterNe, Values: []int{5}},
+	}, testFilterFields)
+	assert.Nil(t, err)
+	assert.Equal(t, "has_parent_set = false AND level >= 10 AND level IN [1, 2] AND level != 5", filter)
+
+	_, err = MeiliFilterFromFieldFilters([]FieldFilter{{Field: "item_count", Operator: FilterEq, Values: []int{3}}}, testFilterFields)
+	assert.NotNil(t, err)
+}
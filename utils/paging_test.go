@@ -2,6 +2,7 @@ package utils
 
 import (
 	"github.com/stretchr/testify/assert"
+	"net/url"
 	"testing"
 )
 
@@ -69,3 +70,16 @@ func TestPaginationFirstSite(t *testing.T) {
 	assert.Equal(t, 0, startIdx)
 	assert.Equal(t, 3, endIdx)
 }
+
+func TestPaginationLinksKeepQuery(t *testing.T) {
+	ApiScheme = "https"
+	ApiHostName = "api.dofusdu.de"
+	pagination := PageninationWithState("2,10")
+	mainUrl, _ := url.Parse("/dofus2/en/items/search?query=gelano&page[number]=2&page[size]=10")
+
+	links, _ := pagination.BuildLinks(*mainUrl, 25)
+
+	assert.Equal(t, "https://api.dofusdu.de/dofus2/en/items/search?page%5Bnumber%5D=1&page%5Bsize%5D=10&query=gelano", *links.Prev)
+	assert.Equal(t, "https://api.dofusdu.de/dofus2/en/items/search?page%5Bnumber%5D=3&page%5Bsize%5D=10&query=gelano", *links.Next)
+	assert.Equal(t, *links.Next, *links.Last)
+}
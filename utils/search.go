@@ -0,0 +1,873 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/meilisearch/meilisearch-go"
+)
+
+const (
+	SearchBackendMeili    = "meili"
+	SearchBackendEmbedded = "embedded"
+)
+
+// Search is the backend selected with SEARCH_BACKEND, it is set by ReadEnvs.
+var Search SearchBackend
+
+// searchMaxValuesPerFacet is enough for every item type of a super type.
+const searchMaxValuesPerFacet = 200
+
+// searchMaxTotalHits is above the number of documents of the largest index. Meilisearch caps the total hits
+// and the reachable pages at 1000 by default.
+const searchMaxTotalHits = 100000
+
+type SearchIndexConfig struct {
+	Uid        string
+	Searchable []string // ordered by importance for the ranking
+	Filterable []string
+	Facets     []string // must also be filterable
+}
+
+type SearchRequest struct {
+	Limit       int64
+	Offset      int64
+	Page        int64 // starts at 1, replaces limit and offset when set together with HitsPerPage
+	HitsPerPage int64
+	Filter      string
+	Facets      []string
+}
+
+type SearchResponse struct {
+	Hits               []map[string]interface{}
+	EstimatedTotalHits int64
+	TotalHits          int64                       // exact count, only set for requests with a page
+	FacetDistribution  map[string]map[string]int64 // attribute to value to count of matching documents
+}
+
+// SearchTask is an asynchronous indexing job of a backend.
+type SearchTask interface {
+	Finished() (bool, error)
+}
+
+type SearchIndex interface {
+	AddDocuments(documents interface{}) (SearchTask, error)
+	Search(query string, request *SearchRequest) (*SearchResponse, error)
+}
+
+type SearchBackend interface {
+	CreateIndex(config *SearchIndexConfig) (SearchIndex, error)
+	Index(uid string) SearchIndex
+	DeleteIndex(uid string) error
+}
+
+func NewSearchBackend(backendType string) (SearchBackend, error) {
+	switch backendType {
+	case SearchBackendMeili:
+		backend, err := NewMeiliSearchBackend()
+		if err != nil {
+			return nil, err
+		}
+		return backend, nil
+	case SearchBackendEmbedded:
+		return NewEmbeddedSearchBackend(), nil
+	}
+	return nil, fmt.Errorf("unknown search backend %q", backendType)
+}
+
+// ---- meili
+
+type MeiliSearchBackend struct {
+	client *meilisearch.Client
+}
+
+type meiliSearchIndex struct {
+	client *meilisearch.Client
+	index  *meilisearch.Index
+}
+
+type meiliSearchTask struct {
+	client  *meilisearch.Client
+	taskUid int64
+}
+
+// NewMeiliSearchBackend fails when Meilisearch can not be reached, so a missing instance shows up at startup
+// and not with every search.
+func NewMeiliSearchBackend() (*MeiliSearchBackend, error) {
+	client := CreateMeiliClient()
+	if !client.IsHealthy() {
+		return nil, fmt.Errorf("meili could not be reached at %s", MeiliHost)
+	}
+	return &MeiliSearchBackend{client: client}, nil
+}
+
+func (b *MeiliSearchBackend) CreateIndex(config *SearchIndexConfig) (SearchIndex, error) {
+	createTask, err := b.client.CreateIndex(&meilisearch.IndexConfig{
+		Uid:        config.Uid,
+		PrimaryKey: "id",
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = b.client.WaitForTask(createTask.TaskUID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := b.client.Index(config.Uid)
+	if len(config.Searchable) > 0 {
+		if _, err = index.UpdateSearchableAttributes(&config.Searchable); err != nil {
+			return nil, err
+		}
+	}
+	if len(config.Filterable) > 0 {
+		if _, err = index.UpdateFilterableAttributes(&config.Filterable); err != nil {
+			return nil, err
+		}
+	}
+	if len(config.Facets) > 0 {
+		if _, err = index.UpdateFaceting(&meilisearch.Faceting{MaxValuesPerFacet: searchMaxValuesPerFacet}); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = index.UpdatePagination(&meilisearch.Pagination{MaxTotalHits: searchMaxTotalHits}); err != nil {
+		return nil, err
+	}
+
+	return &meiliSearchIndex{client: b.client, index: index}, nil
+}
+
+func (b *MeiliSearchBackend) Index(uid string) SearchIndex {
+	return &meiliSearchIndex{client: b.client, index: b.client.Index(uid)}
+}
+
+func (b *MeiliSearchBackend) DeleteIndex(uid string) error {
+	deleteTask, err := b.client.DeleteIndex(uid)
+	if err != nil {
+		return err
+	}
+	_, err = b.client.WaitForTask(deleteTask.TaskUID)
+	return err
+}
+
+func (i *meiliSearchIndex) AddDocuments(documents interface{}) (SearchTask, error) {
+	taskInfo, err := i.index.AddDocuments(documents)
+	if err != nil {
+		return nil, err
+	}
+	return &meiliSearchTask{client: i.client, taskUid: taskInfo.TaskUID}, nil
+}
+
+func (i *meiliSearchIndex) Search(query string, request *SearchRequest) (*SearchResponse, error) {
+	meiliRequest := &meilisearch.SearchRequest{}
+	if request.Page > 0 && request.HitsPerPage > 0 {
+		meiliRequest.Page = request.Page
+		meiliRequest.HitsPerPage = request.HitsPerPage
+	} else {
+		meiliRequest.Limit = request.Limit
+		meiliRequest.Offset = request.Offset
+	}
+	if request.Filter != "" {
+		meiliRequest.Filter = request.Filter
+	}
+	if len(request.Facets) > 0 {
+		meiliRequest.Facets = request.Facets
+	}
+
+	searchResp, err := i.index.Search(query, meiliRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchResponse{
+		Hits:               make([]map[string]interface{}, 0, len(searchResp.Hits)),
+		EstimatedTotalHits: searchResp.EstimatedTotalHits,
+	}
+	if meiliRequest.Page > 0 {
+		// Meilisearch only answers with the exact count for pages
+		response.TotalHits = searchResp.TotalHits
+		response.EstimatedTotalHits = searchResp.TotalHits
+	}
+	for _, hit := range searchResp.Hits {
+		response.Hits = append(response.Hits, hit.(map[string]interface{}))
+	}
+
+	if distribution, ok := searchResp.FacetDistribution.(map[string]interface{}); ok {
+		response.FacetDistribution = make(map[string]map[string]int64)
+		for attribute, values := range distribution {
+			response.FacetDistribution[attribute] = make(map[string]int64)
+			for value, count := range values.(map[string]interface{}) {
+				response.FacetDistribution[attribute][value] = int64(count.(float64))
+			}
+		}
+	}
+	return response, nil
+}
+
+func (t *meiliSearchTask) Finished() (bool, error) {
+	taskResp, err := t.client.GetTask(t.taskUid)
+	if err != nil {
+		return false, err
+	}
+	return taskResp.Status == meilisearch.TaskStatusSucceeded || taskResp.Status == meilisearch.TaskStatusFailed, nil
+}
+
+// ---- embedded
+
+const embeddedDefaultSearchLimit = 20
+
+// EmbeddedSearchBackend keeps the indexes in memory, so the API can serve search without a Meilisearch instance.
+// It supports the subset of Meilisearch features that the API uses: accent folded, typo tolerant prefix search,
+// filters with =, !=, >, >=, <, <= and IN joined by AND and facet distributions.
+type EmbeddedSearchBackend struct {
+	mu      sync.RWMutex
+	indexes map[string]*embeddedSearchIndex
+}
+
+type embeddedDocument struct {
+	fields map[string]interface{}
+	words  [][]searchWord // per searchable attribute
+}
+
+type embeddedSearchIndex struct {
+	mu         sync.RWMutex
+	config     SearchIndexConfig
+	documents  []embeddedDocument
+	positions  map[string]int // primary key to position in documents
+	filterable map[string]bool
+	facets     map[string]bool
+}
+
+type missingSearchIndex struct {
+	uid string
+}
+
+type embeddedSearchTask struct{}
+
+type embeddedHit struct {
+	position   int
+	typos      int
+	attributes int
+	exact      int
+}
+
+type searchFilterClause struct {
+	attribute string
+	operator  string
+	values    []string
+}
+
+var (
+	searchFilterAndRegex    = regexp.MustCompile(`(?i)\s+AND\s+`)
+	searchFilterCommaRegex  = regexp.MustCompile(`,`)
+	searchFilterClauseRegex = regexp.MustCompile(`(?i)^([a-z0-9_.]+)\s*(>=|<=|!=|=|>|<|\s+IN\s+)\s*(.+)$`)
+)
+
+func NewEmbeddedSearchBackend() *EmbeddedSearchBackend {
+	return &EmbeddedSearchBackend{indexes: make(map[string]*embeddedSearchIndex)}
+}
+
+// CreateIndex replaces an index with the same uid, so regenerating into a color whose index was not deleted
+// starts empty instead of failing.
+func (b *EmbeddedSearchBackend) CreateIndex(config *SearchIndexConfig) (SearchIndex, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	index := &embeddedSearchIndex{
+		config:     *config,
+		positions:  make(map[string]int),
+		filterable: make(map[string]bool),
+		facets:     make(map[string]bool),
+	}
+	for _, attribute := range config.Filterable {
+		index.filterable[attribute] = true
+	}
+	for _, attribute := range config.Facets {
+		index.facets[attribute] = true
+	}
+	b.indexes[config.Uid] = index
+	return index, nil
+}
+
+func (b *EmbeddedSearchBackend) Index(uid string) SearchIndex {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if index, ok := b.indexes[uid]; ok {
+		return index
+	}
+	return &missingSearchIndex{uid: uid}
+}
+
+func (b *EmbeddedSearchBackend) DeleteIndex(uid string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.indexes, uid)
+	return nil
+}
+
+func (i *missingSearchIndex) AddDocuments(documents interface{}) (SearchTask, error) {
+	return nil, fmt.Errorf("index %s not found", i.uid)
+}
+
+func (i *missingSearchIndex) Search(query string, request *SearchRequest) (*SearchResponse, error) {
+	return nil, fmt.Errorf("index %s not found", i.uid)
+}
+
+func (t *embeddedSearchTask) Finished() (bool, error) {
+	return true, nil
+}
+
+// AddDocuments takes a slice of structs or maps. Documents are stored like Meilisearch returns them,
+// so numbers are float64 after the JSON round trip. Documents with an existing id replace the old one.
+func (i *embeddedSearchIndex) AddDocuments(documents interface{}) (SearchTask, error) {
+	raw, err := json.Marshal(documents)
+	if err != nil {
+		return nil, err
+	}
+	var docs []map[string]interface{}
+	if err = json.Unmarshal(raw, &docs); err != nil {
+		return nil, err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, fields := range docs {
+		id, ok := fields["id"]
+		if !ok {
+			return nil, errors.New("document without id")
+		}
+		key := fmt.Sprint(id)
+
+		doc := embeddedDocument{fields: fields}
+		for _, attribute := range i.searchableAttributes(fields) {
+			doc.words = append(doc.words, newSearchWords(searchableText(fields[attribute])))
+		}
+
+		if pos, ok := i.positions[key]; ok {
+			i.documents[pos] = doc
+		} else {
+			i.positions[key] = len(i.documents)
+			i.documents = append(i.documents, doc)
+		}
+	}
+	return &embeddedSearchTask{}, nil
+}
+
+// searchableText joins the strings of an attribute, arrays of strings are searchable like in Meilisearch.
+func searchableText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		var parts []string
+		for _, element := range v {
+			if text, ok := element.(string); ok {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
+
+// searchableAttributes falls back to all string attributes sorted by name when the index has no explicit list.
+func (i *embeddedSearchIndex) searchableAttributes(fields map[string]interface{}) []string {
+	if len(i.config.Searchable) > 0 {
+		return i.config.Searchable
+	}
+	var attributes []string
+	for attribute, value := range fields {
+		if _, ok := value.(string); ok {
+			attributes = append(attributes, attribute)
+		}
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+func (i *embeddedSearchIndex) Search(query string, request *SearchRequest) (*SearchResponse, error) {
+	clauses, err := parseSearchFilter(request.Filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, clause := range clauses {
+		if !i.filterable[clause.attribute] {
+			return nil, fmt.Errorf("attribute %s is not filterable", clause.attribute)
+		}
+	}
+
+	for _, attribute := range request.Facets {
+		if !i.facets[attribute] {
+			return nil, fmt.Errorf("attribute %s is not a facet", attribute)
+		}
+	}
+
+	limit, offset := request.Limit, request.Offset
+	if request.Page > 0 && request.HitsPerPage > 0 {
+		limit, offset = request.HitsPerPage, (request.Page-1)*request.HitsPerPage
+	}
+	if limit <= 0 {
+		limit = embeddedDefaultSearchLimit
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var candidates []int
+	for pos := range i.documents {
+		if matchesSearchFilter(i.documents[pos].fields, clauses) {
+			candidates = append(candidates, pos)
+		}
+	}
+
+	// like the default Meilisearch matching strategy, drop words from the end until something matches
+	tokens := SearchTokens(query)
+	var hits []embeddedHit
+	for {
+		hits = hits[:0]
+		matcher := newSearchMatcher(tokens)
+		for _, pos := range candidates {
+			if hit, ok := matcher.matchDocument(&i.documents[pos]); ok {
+				hit.position = pos
+				hits = append(hits, hit)
+			}
+		}
+		if len(hits) > 0 || len(tokens) <= 1 {
+			break
+		}
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].typos != hits[b].typos {
+			return hits[a].typos < hits[b].typos
+		}
+		if hits[a].attributes != hits[b].attributes {
+			return hits[a].attributes < hits[b].attributes
+		}
+		if hits[a].exact != hits[b].exact {
+			return hits[a].exact > hits[b].exact
+		}
+		return hits[a].position < hits[b].position
+	})
+
+	response := &SearchResponse{
+		Hits:               make([]map[string]interface{}, 0, limit),
+		EstimatedTotalHits: int64(len(hits)),
+	}
+	if request.Page > 0 && request.HitsPerPage > 0 {
+		response.TotalHits = int64(len(hits))
+	}
+	for h := offset; h < int64(len(hits)) && int64(len(response.Hits)) < limit; h++ {
+		response.Hits = append(response.Hits, i.documents[hits[h].position].fields)
+	}
+
+	if len(request.Facets) > 0 {
+		response.FacetDistribution = make(map[string]map[string]int64)
+		for _, attribute := range request.Facets {
+			response.FacetDistribution[attribute] = make(map[string]int64)
+		}
+		for _, hit := range hits {
+			fields := i.documents[hit.position].fields
+			for _, attribute := range request.Facets {
+				if value, ok := fields[attribute]; ok && value != nil {
+					response.FacetDistribution[attribute][facetValue(value)]++
+				}
+			}
+		}
+	}
+	return response, nil
+}
+
+// facetValue formats values like Meilisearch does in facet distributions.
+func facetValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(value)
+}
+
+// SearchTokens splits a text into case and accent folded words.
+func SearchTokens(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		tokens = append(tokens, CollationKey(word))
+	}
+	return tokens
+}
+
+// allowedTypos follows the Meilisearch defaults: one typo from 5 characters on, two from 9 on.
+func allowedTypos(token []rune) int {
+	if len(token) >= 9 {
+		return 2
+	}
+	if len(token) >= 5 {
+		return 1
+	}
+	return 0
+}
+
+// searchWord is a folded word of a document, the runes are kept for the typo distance.
+type searchWord struct {
+	text  string
+	runes []rune
+}
+
+func newSearchWords(text string) []searchWord {
+	tokens := SearchTokens(text)
+	words := make([]searchWord, len(tokens))
+	for w, token := range tokens {
+		words[w] = searchWord{text: token, runes: []rune(token)}
+	}
+	return words
+}
+
+type wordMatch struct {
+	typos int
+	exact bool
+	ok    bool
+}
+
+type searchToken struct {
+	searchWord
+	prefix   bool
+	maxTypos int
+	matches  map[string]wordMatch // the same words repeat across documents, so results are cached per word
+}
+
+// searchMatcher holds the tokens of a single search and the rows of the typo distance that are reused
+// between words. It is not safe for concurrent use.
+type searchMatcher struct {
+	tokens []searchToken
+	rows   [3][]int
+}
+
+func newSearchMatcher(tokens []string) *searchMatcher {
+	m := &searchMatcher{tokens: make([]searchToken, len(tokens))}
+	for t, token := range tokens {
+		runes := []rune(token)
+		m.tokens[t] = searchToken{
+			searchWord: searchWord{text: token, runes: runes},
+			prefix:     t == len(tokens)-1,
+			maxTypos:   allowedTypos(runes),
+			matches:    make(map[string]wordMatch),
+		}
+	}
+	return m
+}
+
+// matchDocument checks that every token is found in one of the searchable attributes. Only the last token
+// may match as prefix, so results show up while typing.
+func (m *searchMatcher) matchDocument(doc *embeddedDocument) (embeddedHit, bool) {
+	var hit embeddedHit
+	for t := range m.tokens {
+		token := &m.tokens[t]
+
+		bestTypos, bestAttribute, bestExact := -1, 0, false
+		for attribute, words := range doc.words {
+			for _, word := range words {
+				match := m.matchWord(token, word)
+				if !match.ok {
+					continue
+				}
+				if bestTypos == -1 || match.typos < bestTypos || (match.typos == bestTypos && attribute < bestAttribute) {
+					bestTypos, bestAttribute, bestExact = match.typos, attribute, match.exact
+				}
+			}
+		}
+		if bestTypos == -1 {
+			return hit, false
+		}
+
+		hit.typos += bestTypos
+		hit.attributes += bestAttribute
+		if bestExact {
+			hit.exact++
+		}
+	}
+	return hit, true
+}
+
+func (m *searchMatcher) matchWord(token *searchToken, word searchWord) wordMatch {
+	if match, ok := token.matches[word.text]; ok {
+		return match
+	}
+	match := m.computeMatch(token, word)
+	token.matches[word.text] = match
+	return match
+}
+
+func (m *searchMatcher) computeMatch(token *searchToken, word searchWord) wordMatch {
+	if token.text == word.text {
+		return wordMatch{exact: true, ok: true}
+	}
+	if token.prefix && strings.HasPrefix(word.text, token.text) {
+		return wordMatch{ok: true}
+	}
+	if token.maxTypos == 0 {
+		return wordMatch{}
+	}
+
+	// the distance is at least the difference of the lengths, so most words are skipped without computing it
+	typos := token.maxTypos + 1
+	if lengthDifference(len(token.runes), len(word.runes)) <= token.maxTypos {
+		typos = m.typoDistance(token.runes, word.runes, token.maxTypos)
+	}
+	if token.prefix && typos > 0 && len(word.runes) > len(token.runes) {
+		if prefixTypos := m.typoDistance(token.runes, word.runes[:len(token.runes)], token.maxTypos); prefixTypos < typos {
+			typos = prefixTypos
+		}
+	}
+	if typos > token.maxTypos {
+		return wordMatch{}
+	}
+	return wordMatch{typos: typos, ok: true}
+}
+
+func lengthDifference(a int, b int) int {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// typoDistance is the edit distance where swapping two neighbouring letters counts as a single typo. It stops
+// with maxTypos+1 as soon as a row has no value within maxTypos, because the rows below can only be larger.
+func (m *searchMatcher) typoDistance(a []rune, b []rune, maxTypos int) int {
+	for r := range m.rows {
+		if cap(m.rows[r]) < len(b)+1 {
+			m.rows[r] = make([]int, len(b)+1)
+		}
+		m.rows[r] = m.rows[r][:len(b)+1]
+	}
+	before, previous, current := m.rows[0], m.rows[1], m.rows[2]
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		rowMin := i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d := previous[j] + 1
+			if current[j-1]+1 < d {
+				d = current[j-1] + 1
+			}
+			if previous[j-1]+cost < d {
+				d = previous[j-1] + cost
+			}
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && before[j-2]+1 < d {
+				d = before[j-2] + 1
+			}
+			current[j] = d
+			if d < rowMin {
+				rowMin = d
+			}
+		}
+		if rowMin > maxTypos {
+			return maxTypos + 1
+		}
+		before, previous, current = previous, current, before
+	}
+	if previous[len(b)] > maxTypos {
+		return maxTypos + 1
+	}
+	return previous[len(b)]
+}
+
+func parseSearchFilter(filter string) ([]searchFilterClause, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil, nil
+	}
+
+	parts, err := splitSearchFilter(filter, searchFilterAndRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	var clauses []searchFilterClause
+	for _, part := range parts {
+		matches := searchFilterClauseRegex.FindStringSubmatch(strings.TrimSpace(part))
+		if matches == nil {
+			return nil, fmt.Errorf("invalid filter %q", part)
+		}
+
+		clause := searchFilterClause{
+			attribute: matches[1],
+			operator:  strings.ToUpper(strings.TrimSpace(matches[2])),
+		}
+		value := strings.TrimSpace(matches[3])
+		if clause.operator == "IN" {
+			if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+				return nil, fmt.Errorf("invalid filter %q", part)
+			}
+			inValues, err := splitSearchFilter(value[1:len(value)-1], searchFilterCommaRegex)
+			if err != nil {
+				return nil, err
+			}
+			for _, inValue := range inValues {
+				clause.values = append(clause.values, trimFilterValue(inValue))
+			}
+		} else {
+			clause.values = []string{trimFilterValue(value)}
+		}
+		clauses = append(clauses, clause)
+	}
+	return clauses, nil
+}
+
+// splitSearchFilter splits at the separators outside of quoted values, so quoted values may contain AND or commas.
+func splitSearchFilter(filter string, separator *regexp.Regexp) ([]string, error) {
+	quoted, err := quotedFilterRanges(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []string
+	start := 0
+	for _, match := range separator.FindAllStringIndex(filter, -1) {
+		inQuotes := false
+		for _, quote := range quoted {
+			if match[0] > quote[0] && match[0] < quote[1] {
+				inQuotes = true
+				break
+			}
+		}
+		if inQuotes {
+			continue
+		}
+		parts = append(parts, filter[start:match[0]])
+		start = match[1]
+	}
+	return append(parts, filter[start:]), nil
+}
+
+// quotedFilterRanges returns the start and end positions of the quotes around values. Like in Meilisearch a value
+// is quoted with " or ' and quotes in it are escaped with a backslash. A quote within an unquoted value,
+// like the apostrophe in a name, does not start a quoted value.
+func quotedFilterRanges(filter string) ([][2]int, error) {
+	var ranges [][2]int
+	var quote byte
+	start := 0
+	for pos := 0; pos < len(filter); pos++ {
+		c := filter[pos]
+		switch {
+		case quote == 0 && (c == '"' || c == '\'') && (pos == 0 || strings.ContainsRune(" \t=<>[,", rune(filter[pos-1]))):
+			quote, start = c, pos
+		case quote != 0 && c == '\\':
+			pos++
+		case quote != 0 && c == quote:
+			ranges = append(ranges, [2]int{start, pos})
+			quote = 0
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in filter %q", filter)
+	}
+	return ranges, nil
+}
+
+// trimFilterValue removes the quotes around a value and unescapes the quotes in it.
+func trimFilterValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		quote := value[:1]
+		return strings.ReplaceAll(value[1:len(value)-1], `\`+quote, quote)
+	}
+	return value
+}
+
+func matchesSearchFilter(fields map[string]interface{}, clauses []searchFilterClause) bool {
+	for _, clause := range clauses {
+		value, ok := fields[clause.attribute]
+		if clause.operator == "!=" {
+			if ok && matchesFilterValue(value, "=", clause.values[0]) {
+				return false
+			}
+			continue
+		}
+		if !ok {
+			return false
+		}
+
+		operator := clause.operator
+		if operator == "IN" {
+			operator = "="
+		}
+		found := false
+		for _, expected := range clause.values {
+			if matchesFilterValue(value, operator, expected) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesFilterValue compares a document value with the filter, for arrays a single matching element is enough.
+func matchesFilterValue(value interface{}, operator string, expected string) bool {
+	if values, ok := value.([]interface{}); ok {
+		for _, element := range values {
+			if matchesFilterValue(element, operator, expected) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if operator == "=" {
+		return filterValueEquals(value, expected)
+	}
+	number, isNumber := value.(float64)
+	expectedNumber, err := strconv.ParseFloat(expected, 64)
+	return isNumber && err == nil && compareFilterNumbers(number, operator, expectedNumber)
+}
+
+func filterValueEquals(value interface{}, expected string) bool {
+	switch v := value.(type) {
+	case float64:
+		number, err := strconv.ParseFloat(expected, 64)
+		return err == nil && v == number
+	case bool:
+		return strconv.FormatBool(v) == strings.ToLower(expected)
+	case string:
+		return strings.EqualFold(v, expected)
+	}
+	return false
+}
+
+func compareFilterNumbers(actual float64, operator string, expected float64) bool {
+	switch operator {
+	case ">=":
+		return actual >= expected
+	case "<=":
+		return actual <= expected
+	case ">":
+		return actual > expected
+	case "<":
+		return actual < expected
+	}
+	return false
+}
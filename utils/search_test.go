@@ -0,0 +1,275 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testSearchDocument struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	TypeName string `json:"type_name"`
+	Level    int    `json:"level"`
+	HasSet   bool   `json:"has_parent_set"`
+}
+
+func createTestSearchIndex(t *testing.T) SearchIndex {
+	backend := NewEmbeddedSearchBackend()
+	index, err := backend.CreateIndex(&SearchIndexConfig{
+		Uid:        "red-all_items-fr",
+		Searchable: []string{"name", "type_name"},
+		Filterable: []string{"type_name", "level", "has_parent_set"},
+		Facets:     []string{"type_name", "level"},
+	})
+	assert.Nil(t, err)
+
+	task, err := index.AddDocuments([]testSearchDocument{
+		{Id: 1, Name: "Épée de Boisaille", TypeName: "épée", Level: 20, HasSet: true},
+		{Id: 2, Name: "Anneau du Bouftou", TypeName: "anneau", Level: 10, HasSet: true},
+		{Id: 3, Name: "Amulette du Bouftou", TypeName: "amulette", Level: 12},
+		{Id: 4, Name: "Gelano", TypeName: "anneau", Level: 60},
+	})
+	assert.Nil(t, err)
+	finished, err := task.Finished()
+	assert.Nil(t, err)
+	assert.True(t, finished)
+
+	return index
+}
+
+func searchHitIds(response *SearchResponse) []int {
+	var ids []int
+	for _, hit := range response.Hits {
+		ids = append(ids, int(hit["id"].(float64)))
+	}
+	return ids
+}
+
+func TestSearchTokens(t *testing.T) {
+	assert.Equal(t, []string{"epee", "de", "l", "aventurier"}, SearchTokens("Épée de l'Aventurier"))
+	assert.Empty(t, SearchTokens(" - "))
+}
+
+func TestEmbeddedSearchAccentsAndPrefix(t *testing.T) {
+	index := createTestSearchIndex(t)
+
+	response, err := index.Search("epee", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, searchHitIds(response))
+
+	response, err = index.Search("bouf", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3}, searchHitIds(response))
+	assert.Equal(t, int64(2), response.EstimatedTotalHits)
+}
+
+func TestEmbeddedSearchTypos(t *testing.T) {
+	index := createTestSearchIndex(t)
+
+	response, err := index.Search("bouftuo", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3}, searchHitIds(response))
+
+	// short words must match exactly
+	response, err = index.Search("gel", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4}, searchHitIds(response))
+	response, err = index.Search("gal", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, response.Hits)
+}
+
+func TestEmbeddedSearchRanking(t *testing.T) {
+	index := createTestSearchIndex(t)
+
+	// name matches rank before type name matches
+	response, err := index.Search("anneau", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 4}, searchHitIds(response))
+
+	// unmatched trailing words are dropped
+	response, err = index.Search("amulette xyz", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3}, searchHitIds(response))
+
+	response, err = index.Search("bouftou", &SearchRequest{Limit: 1})
+	assert.Nil(t, err)
+	assert.Len(t, response.Hits, 1)
+	assert.Equal(t, int64(2), response.EstimatedTotalHits)
+}
+
+func TestEmbeddedSearchOffset(t *testing.T) {
+	index := createTestSearchIndex(t)
+
+	response, err := index.Search("bouftou", &SearchRequest{Limit: 1, Offset: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3}, searchHitIds(response))
+	assert.Equal(t, int64(2), response.EstimatedTotalHits)
+
+	response, err = index.Search("bouftou", &SearchRequest{Limit: 1, Offset: 2})
+	assert.Nil(t, err)
+	assert.Empty(t, response.Hits)
+}
+
+func TestEmbeddedSearchPage(t *testing.T) {
+	index := createTestSearchIndex(t)
+
+	response, err := index.Search("bouftou", &SearchRequest{Page: 2, HitsPerPage: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3}, searchHitIds(response))
+	assert.Equal(t, int64(2), response.TotalHits)
+
+	response, err = index.Search("bouftou", &SearchRequest{Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2}, searchHitIds(response))
+	assert.Equal(t, int64(0), response.TotalHits)
+}
+
+func TestEmbeddedSearchFilter(t *testing.T) {
+	index := createTestSearchIndex(t)
+
+	response, err := index.Search("bouftou", &SearchRequest{Filter: "level>=11 AND has_parent_set = false"})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3}, searchHitIds(response))
+
+	response, err = index.Search("anneau", &SearchRequest{Filter: "type_name IN [anneau, amulette] AND level != 10"})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4}, searchHitIds(response))
+
+	response, err = index.Search("epee", &SearchRequest{Filter: "type_name=Épée"})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, searchHitIds(response))
+
+	_, err = index.Search("epee", &SearchRequest{Filter: "name=epee"})
+	assert.NotNil(t, err)
+	_, err = index.Search("epee", &SearchRequest{Filter: "level >"})
+	assert.NotNil(t, err)
+}
+
+func TestEmbeddedSearchArrayAttributes(t *testing.T) {
+	backend := NewEmbeddedSearchBackend()
+	index, err := backend.CreateIndex(&SearchIndexConfig{
+		Uid:        "red-sets-en",
+		Searchable: []string{"name", "effects"},
+		Filterable: []string{"effects", "effect_element_ids"},
+	})
+	assert.Nil(t, err)
+	_, err = index.AddDocuments([]map[string]interface{}{
+		{"id": 1, "name": "Gobball Set", "effects": []string{"vitality", "critical hit"}, "effect_element_ids": []int{3, 7}},
+		{"id": 2, "name": "Boon Set", "effects": []string{"wisdom"}, "effect_element_ids": []int{9}},
+		{"id": 3, "name": "Empty Set"},
+	})
+	assert.Nil(t, err)
+
+	response, err := index.Search("critical", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, searchHitIds(response))
+
+	response, err = index.Search("set", &SearchRequest{Filter: `effects = "critical hit" AND effect_element_ids >= 5`})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, searchHitIds(response))
+
+	response, err = index.Search("set", &SearchRequest{Filter: "effect_element_ids != 3"})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3}, searchHitIds(response))
+
+	response, err = index.Search("nil", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, response.Hits)
+}
+
+func TestEmbeddedSearchQuotedFilter(t *testing.T) {
+	backend := NewEmbeddedSearchBackend()
+	index, err := backend.CreateIndex(&SearchIndexConfig{
+		Uid:        "red-all_items-en",
+		Searchable: []string{"name"},
+		Filterable: []string{"type_name", "level"},
+	})
+	assert.Nil(t, err)
+	_, err = index.AddDocuments([]testSearchDocument{
+		{Id: 1, Name: "Gobball Sword", TypeName: "sword and shield", Level: 20},
+		{Id: 2, Name: "Gobball Ring", TypeName: "ring, amulet", Level: 10},
+		{Id: 3, Name: "Gobball Hat", TypeName: `the "best" hat`, Level: 30},
+		{Id: 4, Name: "Gobball Cloak", TypeName: "Ankama's cloak", Level: 40},
+	})
+	assert.Nil(t, err)
+
+	response, err := index.Search("gobball", &SearchRequest{Filter: `type_name = "sword and shield" AND level >= 10`})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, searchHitIds(response))
+
+	response, err = index.Search("gobball", &SearchRequest{Filter: `type_name IN ['ring, amulet', "sword AND shield"]`})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, searchHitIds(response))
+
+	response, err = index.Search("gobball", &SearchRequest{Filter: `type_name = "the \"best\" hat"`})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3}, searchHitIds(response))
+
+	response, err = index.Search("gobball", &SearchRequest{Filter: "type_name = Ankama's cloak AND level > 30"})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4}, searchHitIds(response))
+
+	_, err = index.Search("gobball", &SearchRequest{Filter: `type_name = "sword and shield`})
+	assert.NotNil(t, err)
+}
+
+func TestSearchMatcherTypoDistance(t *testing.T) {
+	matcher := newSearchMatcher(nil)
+	assert.Equal(t, 0, matcher.typoDistance([]rune("bouftou"), []rune("bouftou"), 2))
+	assert.Equal(t, 1, matcher.typoDistance([]rune("bouftuo"), []rune("bouftou"), 2))
+	assert.Equal(t, 1, matcher.typoDistance([]rune("boufto"), []rune("bouftou"), 2))
+	assert.Equal(t, 2, matcher.typoDistance([]rune("bofutuo"), []rune("bouftou"), 2))
+	// stops once the distance exceeds the allowed typos
+	assert.Equal(t, 2, matcher.typoDistance([]rune("amulette"), []rune("bouftou"), 1))
+	assert.Equal(t, 3, matcher.typoDistance([]rune("abc"), []rune(""), 3))
+}
+
+func TestEmbeddedSearchTyposCachedWords(t *testing.T) {
+	index := createTestSearchIndex(t)
+
+	// the same word in several documents matches with the same typos
+	response, err := index.Search("bouftoo anneau", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2}, searchHitIds(response))
+
+	response, err = index.Search("boufto", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3}, searchHitIds(response))
+}
+
+func TestEmbeddedSearchFacets(t *testing.T) {
+	index := createTestSearchIndex(t)
+
+	response, err := index.Search("bouftou", &SearchRequest{Limit: 1, Facets: []string{"type_name", "level"}})
+	assert.Nil(t, err)
+	assert.Len(t, response.Hits, 1)
+	assert.Equal(t, map[string]int64{"anneau": 1, "amulette": 1}, response.FacetDistribution["type_name"])
+	assert.Equal(t, map[string]int64{"10": 1, "12": 1}, response.FacetDistribution["level"])
+
+	response, err = index.Search("bouftou", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Nil(t, response.FacetDistribution)
+
+	_, err = index.Search("bouftou", &SearchRequest{Facets: []string{"has_parent_set"}})
+	assert.NotNil(t, err)
+}
+
+func TestEmbeddedSearchBackendIndexes(t *testing.T) {
+	backend := NewEmbeddedSearchBackend()
+	index, err := backend.CreateIndex(&SearchIndexConfig{Uid: "blue-sets-de"})
+	assert.Nil(t, err)
+	_, err = index.AddDocuments([]map[string]interface{}{{"id": 1, "name": "Set"}})
+	assert.Nil(t, err)
+
+	// an existing index is replaced by an empty one
+	_, err = backend.CreateIndex(&SearchIndexConfig{Uid: "blue-sets-de"})
+	assert.Nil(t, err)
+	response, err := backend.Index("blue-sets-de").Search("set", &SearchRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, response.Hits)
+
+	assert.Nil(t, backend.DeleteIndex("blue-sets-de"))
+	_, err = backend.Index("blue-sets-de").Search("set", &SearchRequest{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const effectSortPrefix = "effect:"
+
+// SortKey is a single entry of a sort parameter like "sort=-level,name".
+type SortKey struct {
+	Field     string
+	ElementId int // only set for effect keys
+	Desc      bool
+}
+
+// ParseSort reads the sort parameter. The older sort[level]=asc|desc form is still accepted and used
+// when no sort parameter is given. Effect values are sorted with "effect:<element id>".
+func ParseSort(sortParam string, sortLevel string, allowed []string) ([]SortKey, error) {
+	if sortParam == "" {
+		switch strings.ToLower(sortLevel) {
+		case "asc":
+			return []SortKey{{Field: "level"}}, nil
+		case "desc":
+			return []SortKey{{Field: "level", Desc: true}}, nil
+		}
+		return nil, nil
+	}
+
+	var keys []SortKey
+	for _, part := range strings.Split(sortParam, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		key := SortKey{}
+		if strings.HasPrefix(part, "-") {
+			key.Desc = true
+			part = part[1:]
+		} else {
+			part = strings.TrimPrefix(part, "+")
+		}
+
+		if strings.HasPrefix(part, effectSortPrefix) {
+			elementId, err := strconv.Atoi(strings.TrimPrefix(part, effectSortPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("sort %s", part)
+			}
+			key.Field = "effect"
+			key.ElementId = elementId
+		} else {
+			key.Field = part
+		}
+
+		known := false
+		for _, field := range allowed {
+			known = known || field == key.Field
+		}
+		if !known {
+			return nil, fmt.Errorf("sort %s", part)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func CompareInt(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// LessByKeys decides with the first key that does not compare equal.
+func LessByKeys(keys []SortKey, compare func(i, j int, key SortKey) int) func(i, j int) bool {
+	return func(i, j int) bool {
+		for _, key := range keys {
+			c := compare(i, j, key)
+			if key.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	}
+}
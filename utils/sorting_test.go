@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	allowed := []string{"level", "name", "effect"}
+	tests := []struct {
+		sort      string
+		sortLevel string
+		keys      []SortKey
+		err       bool
+	}{
+		{sort: "", sortLevel: "", keys: nil},
+		{sort: "", sortLevel: "asc", keys: []SortKey{{Field: "level"}}},
+		{sort: "", sortLevel: "DESC", keys: []SortKey{{Field: "level", Desc: true}}},
+		{sort: "-level,name", sortLevel: "asc", keys: []SortKey{{Field: "level", Desc: true}, {Field: "name"}}},
+		{sort: "+name", keys: []SortKey{{Field: "name"}}},
+		{sort: " Name , -effect:12", keys: []SortKey{{Field: "name"}, {Field: "effect", ElementId: 12, Desc: true}}},
+		{sort: "pods", err: true},
+		{sort: "effect:abc", err: true},
+		{sort: "level,", err: true},
+	}
+
+	for _, test := range tests {
+		keys, err := ParseSort(test.sort, test.sortLevel, allowed)
+		if test.err {
+			assert.NotNil(t, err, test.sort)
+			continue
+		}
+		assert.Nil(t, err, test.sort)
+		assert.Equal(t, test.keys, keys, test.sort)
+	}
+}
+
+func TestLessByKeys(t *testing.T) {
+	type entry struct {
+		level int
+		name  string
+	}
+	tests := []struct {
+		keys  []SortKey
+		order []string
+	}{
+		{keys: []SortKey{{Field: "level"}}, order: []string{"b", "c", "a"}},
+		{keys: []SortKey{{Field: "level", Desc: true}}, order: []string{"a", "b", "c"}},
+		{keys: []SortKey{{Field: "level"}, {Field: "name", Desc: true}}, order: []string{"c", "b", "a"}},
+		{keys: []SortKey{{Field: "name"}}, order: []string{"a", "b", "c"}},
+	}
+
+	for _, test := range tests {
+		entries := []entry{{level: 20, name: "a"}, {level: 10, name: "b"}, {level: 10, name: "c"}}
+		sort.SliceStable(entries, LessByKeys(test.keys, func(i, j int, key SortKey) int {
+			switch key.Field {
+			case "level":
+				return CompareInt(entries[i].level, entries[j].level)
+			case "name":
+				return CompareCollated(entries[i].name, entries[j].name)
+			}
+			return 0
+		}))
+
+		var order []string
+		for _, e := range entries {
+			order = append(order, e.name)
+		}
+		assert.Equal(t, test.order, order, test.keys)
+	}
+}
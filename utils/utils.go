@@ -149,6 +149,16 @@ func ReadEnvs() {
 
 	MeiliHost = fmt.Sprintf("%s://%s:%s", meiliProtocol, meiliHost, meiliPort)
 
+	searchBackend, ok := os.LookupEnv("SEARCH_BACKEND")
+	if !ok {
+		searchBackend = SearchBackendMeili
+	}
+
+	Search, err = NewSearchBackend(strings.ToLower(searchBackend))
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	promEnables, ok := os.LookupEnv("PROMETHEUS")
 	if !ok {
 		promEnables = ""
@@ -515,15 +525,18 @@ func (p *Pagination) BuildLinks(mainUrl url.URL, listSize int) (PaginationLinks,
 
 	baseUrl, _ := url.JoinPath(fmt.Sprintf("%s://%s", ApiScheme, ApiHostName), mainUrl.Path)
 
-	firstUrlQuery := fmt.Sprintf("page[number]=%d&page[size]=%d", firstPage, p.PageSize)
-	prevUrlQuery := fmt.Sprintf("page[number]=%d&page[size]=%d", p.PageNumber-1, p.PageSize)
-	nextUrlQuery := fmt.Sprintf("page[number]=%d&page[size]=%d", p.PageNumber+1, p.PageSize)
-	lastUrlQuery := fmt.Sprintf("page[number]=%d&page[size]=%d", lastPage, p.PageSize)
+	// keep the other params like filters or the search query in the links
+	pageUrlQuery := func(pageNumber int) string {
+		query := mainUrl.Query()
+		query.Set("page[number]", strconv.Itoa(pageNumber))
+		query.Set("page[size]", strconv.Itoa(p.PageSize))
+		return query.Encode()
+	}
 
-	firstUrlStr := url.QueryEscape(firstUrlQuery)
-	prevUrlStr := url.QueryEscape(prevUrlQuery)
-	nextUrlStr := url.QueryEscape(nextUrlQuery)
-	lastUrlStr := url.QueryEscape(lastUrlQuery)
+	firstUrlStr := pageUrlQuery(firstPage)
+	prevUrlStr := pageUrlQuery(p.PageNumber - 1)
+	nextUrlStr := pageUrlQuery(p.PageNumber + 1)
+	lastUrlStr := pageUrlQuery(lastPage)
 
 	firstUrl := fmt.Sprintf("%s?%s", baseUrl, firstUrlStr)
 	prevUrl := fmt.Sprintf("%s?%s", baseUrl, prevUrlStr)